@@ -15,8 +15,10 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	isrc "github.com/hashicorp/hc-install/internal/src"
+	"github.com/hashicorp/hc-install/internal/tempdir"
 	"github.com/hashicorp/hc-install/internal/validators"
 	"github.com/hashicorp/hc-install/product"
+	"github.com/hashicorp/hc-install/src"
 )
 
 var (
@@ -48,6 +50,9 @@ type GitRevision struct {
 
 	logger        *log.Logger
 	pathsToRemove []string
+	revision      string
+	licensePaths  []string
+	artifact      *src.Artifact
 }
 
 func (*GitRevision) IsSourceImpl() isrc.InstallSrcSigil {
@@ -69,8 +74,14 @@ func (gr *GitRevision) Validate() error {
 	if !validators.IsProductNameValid(gr.Product.Name) {
 		return fmt.Errorf("invalid product name: %q", gr.Product.Name)
 	}
-	if !validators.IsBinaryNameValid(gr.Product.BinaryName()) {
-		return fmt.Errorf("invalid binary name: %q", gr.Product.BinaryName())
+	// version of the built binary isn't known upfront
+	target := product.CurrentTarget(nil)
+	if !gr.Product.BinaryNameKnown(target) {
+		return fmt.Errorf("binary name depends on version, which isn't known before building (declare BinaryName)")
+	}
+	binaryName := gr.Product.BinaryNameFor(target)
+	if !validators.IsBinaryNameValid(binaryName) {
+		return fmt.Errorf("invalid binary name: %q", binaryName)
 	}
 
 	bi := gr.Product.BuildInstructions
@@ -112,12 +123,16 @@ func (gr *GitRevision) Build(ctx context.Context) (string, error) {
 		gr.pathsToRemove = make([]string, 0)
 	}
 
-	repoDir, err := os.MkdirTemp("",
+	cloneDir, err := tempdir.MkdirTemp(
 		fmt.Sprintf("hc-install-build-%s", gr.Product.Name))
 	if err != nil {
 		return "", err
 	}
-	gr.pathsToRemove = append(gr.pathsToRemove, repoDir)
+	gr.pathsToRemove = append(gr.pathsToRemove, cloneDir)
+
+	// clone into a subdirectory, so that the repository
+	// doesn't contain the marker of the temp dir
+	repoDir := filepath.Join(cloneDir, "src")
 
 	ref := gr.Ref
 	if ref == "" {
@@ -154,6 +169,7 @@ func (gr *GitRevision) Build(ctx context.Context) (string, error) {
 	}
 
 	gr.log().Printf("%s repository HEAD is at %s", gr.Product.Name, head.Hash())
+	gr.revision = head.Hash().String()
 
 	buildTimeout := defaultBuildTimeout
 	if bi.BuildTimeout > 0 {
@@ -171,7 +187,7 @@ func (gr *GitRevision) Build(ctx context.Context) (string, error) {
 	}
 	installDir := gr.InstallDir
 	if installDir == "" {
-		tmpDir, err := os.MkdirTemp("",
+		tmpDir, err := tempdir.MkdirTemp(
 			fmt.Sprintf("hc-install-%s-%s", gr.Product.Name, head.Hash()))
 		if err != nil {
 			return "", err
@@ -194,7 +210,30 @@ func (gr *GitRevision) Build(ctx context.Context) (string, error) {
 
 	gr.log().Printf("building %s (timeout: %s)", gr.Product.Name, buildTimeout)
 	defer gr.log().Printf("building of %s finished", gr.Product.Name)
-	return bi.Build.Build(buildCtx, repoDir, installDir, gr.Product.BinaryName())
+	execPath, err := bi.Build.Build(buildCtx, repoDir, installDir,
+		gr.Product.BinaryNameFor(product.CurrentTarget(nil)))
+	if err != nil {
+		return "", err
+	}
+
+	gr.artifact = &src.Artifact{
+		LicensePaths: gr.licensePaths,
+	}
+
+	return execPath, nil
+}
+
+// Artifact returns details of the binary built by the last
+// successful Build, or nil if nothing was built yet
+func (gr *GitRevision) Artifact() *src.Artifact {
+	return gr.artifact
+}
+
+// Revision returns the commit hash which was checked out
+// and built by the last call to Build, or empty string
+// if no repository was cloned yet
+func (gr *GitRevision) Revision() string {
+	return gr.revision
 }
 
 func (gr *GitRevision) copyLicenseIfExists(repoDir string, dstDir string) error {
@@ -235,13 +274,14 @@ func (gr *GitRevision) copyLicenseFile(srcPath, dstPath string) error {
 		srcPath, dstPath, n)
 	// Add the license file to the list of paths to remove after being successfully copied
 	gr.pathsToRemove = append(gr.pathsToRemove, dstPath)
+	gr.licensePaths = append(gr.licensePaths, dstPath)
 	return nil
 }
 
 func (gr *GitRevision) Remove(ctx context.Context) error {
 	if gr.pathsToRemove != nil {
 		for _, path := range gr.pathsToRemove {
-			err := os.RemoveAll(path)
+			err := tempdir.RemoveAll(path)
 			if err != nil {
 				return fmt.Errorf("failed to remove %q: %w", path, err)
 			}
@@ -40,6 +40,10 @@ func TestGitRevision_terraform(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	if gr.Revision() == "" {
+		t.Fatal("expected revision of built commit to be recorded")
+	}
+
 	licensePath := filepath.Join(tempDir, dstLicenseFileName)
 	t.Cleanup(func() {
 		gr.Remove(ctx)
@@ -143,6 +147,17 @@ func TestGitRevisionValidate(t *testing.T) {
 			},
 			expectedErr: fmt.Errorf("invalid binary name: \"invalid!\""),
 		},
+		"Product-version-dependent-binary-name": {
+			gr: GitRevision{
+				Product: product.Product{
+					TargetBinaryName: func(t product.Target) string {
+						return "terraform_v" + t.Version.String()
+					},
+					Name: product.Terraform.Name,
+				},
+			},
+			expectedErr: fmt.Errorf("binary name depends on version, which isn't known before building (declare BinaryName)"),
+		},
 		"Product-incorrect-name": {
 			gr: GitRevision{
 				Product: product.Product{
@@ -0,0 +1,82 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	checkpoint "github.com/hashicorp/go-checkpoint"
+)
+
+const defaultCheckpointURL = "https://checkpoint-api.hashicorp.com"
+
+// Alert represents an alert published via Checkpoint,
+// e.g. about a security issue affecting the product
+type Alert struct {
+	ID      int
+	Date    time.Time
+	Message string
+	URL     string
+
+	// Level represents severity of the alert (e.g. info, warn or critical)
+	Level string
+}
+
+func alertsFromResponse(resp *checkpoint.CheckResponse) []*Alert {
+	alerts := make([]*Alert, 0, len(resp.Alerts))
+	for _, a := range resp.Alerts {
+		alerts = append(alerts, &Alert{
+			ID:      a.ID,
+			Date:    time.Unix(int64(a.Date), 0),
+			Message: a.Message,
+			URL:     a.URL,
+			Level:   a.Level,
+		})
+	}
+	return alerts
+}
+
+// check performs the same request as checkpoint.Check,
+// but honours the context and the given Checkpoint URL
+func check(ctx context.Context, client *http.Client, logger *log.Logger, checkpointURL string, p *checkpoint.CheckParams) (*checkpoint.CheckResponse, error) {
+	q := url.Values{}
+	q.Set("version", p.Version)
+	q.Set("arch", p.Arch)
+	q.Set("os", p.OS)
+	q.Set("signature", p.Signature)
+
+	checkURL := fmt.Sprintf("%s/v1/check/%s?%s",
+		checkpointURL, url.PathEscape(p.Product), q.Encode())
+	logger.Printf("checking latest version via %s", checkURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, checkURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %q: %w", checkURL, err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to check latest version via %q: %s", checkURL, resp.Status)
+	}
+
+	cr := &checkpoint.CheckResponse{}
+	err = json.NewDecoder(resp.Body).Decode(cr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint response: %w", err)
+	}
+
+	return cr, nil
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -15,11 +16,19 @@ import (
 
 	checkpoint "github.com/hashicorp/go-checkpoint"
 	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/internal/httpclient"
 	"github.com/hashicorp/hc-install/internal/pubkey"
-	rjson "github.com/hashicorp/hc-install/internal/releasesjson"
+	"github.com/hashicorp/hc-install/internal/release"
 	isrc "github.com/hashicorp/hc-install/internal/src"
+	"github.com/hashicorp/hc-install/internal/tempdir"
 	"github.com/hashicorp/hc-install/internal/validators"
+	"github.com/hashicorp/hc-install/internal/versioncheck"
+	"github.com/hashicorp/hc-install/policy"
 	"github.com/hashicorp/hc-install/product"
+	"github.com/hashicorp/hc-install/receipt"
+	"github.com/hashicorp/hc-install/registry"
+	rjson "github.com/hashicorp/hc-install/releasesjson"
+	"github.com/hashicorp/hc-install/src"
 )
 
 var (
@@ -35,6 +44,28 @@ type LatestVersion struct {
 	SkipChecksumVerification bool
 	InstallDir               string
 
+	// WriteReceipt indicates that an install receipt recording
+	// the provenance of the binary (see package receipt)
+	// should be written next to it
+	WriteReceipt bool
+
+	// VerifyVersion indicates that the installed binary has to report
+	// the installed version (including any enterprise metadata) via
+	// Product.GetVersion, e.g. to detect mislabeled archives served
+	// by a mirror. *errors.VersionMismatchError is returned otherwise,
+	// once the install is rolled back (restoring any overwritten files).
+	VerifyVersion bool
+
+	// PreferBuildInfo indicates that VerifyVersion reads the version
+	// without executing the binary (via Product.ReadVersion, e.g. from
+	// Go build info), falling back to executing it via Product.GetVersion
+	PreferBuildInfo bool
+
+	// Registry represents an optional on-disk registry where files created
+	// by the install are recorded, such that they can be removed
+	// by another process (see package registry)
+	Registry *registry.Registry
+
 	// LicenseDir represents directory path where to install license files.
 	// If empty, license files will placed in the same directory as the binary.
 	LicenseDir string
@@ -43,8 +74,42 @@ type LatestVersion struct {
 	// instead of built-in pubkey to verify signature of downloaded checksums
 	ArmoredPublicKey string
 
-	logger        *log.Logger
-	pathsToRemove []string
+	// AdditionalArmoredPublicKeys are public PGP keys in ASCII/armor format
+	// which are trusted alongside the built-in pubkey (or ArmoredPublicKey),
+	// e.g. during key rotation or when a mirror re-signs the checksums
+	AdditionalArmoredPublicKeys []string
+
+	// Sigstore enables verification of Sigstore bundle of the checksums
+	// in addition to the PGP signature (leave nil to verify PGP only)
+	Sigstore *src.SigstoreOptions
+
+	// CheckpointURL is an optional field that specifies a custom URL
+	// of the Checkpoint service to obtain the latest version from.
+	CheckpointURL string
+
+	// ApiBaseURL is an optional field that specifies a custom URL to download the product from.
+	// If ApiBaseURL is set, the product will be downloaded from this base URL instead of the default site.
+	// Note: The directory structure of the custom URL must match the HashiCorp releases site (including the index.json files).
+	ApiBaseURL string
+
+	// HTTPClient represents an optional client used for all requests,
+	// e.g. to configure proxy or TLS settings of a mirror
+	HTTPClient *http.Client
+
+	// Policy represents an optional policy which has to allow
+	// the latest version before it is downloaded
+	Policy policy.Policy
+
+	logger          *log.Logger
+	pathsToRemove   []string
+	alerts          []*Alert
+	artifact        *src.Artifact
+	installerPolicy policy.Policy
+
+	installerRegistry *registry.Registry
+	registryEntry     *registry.Entry
+	backupPaths       map[string]string
+	removableItems    []*registry.Item
 }
 
 func (*LatestVersion) IsSourceImpl() isrc.InstallSrcSigil {
@@ -55,6 +120,23 @@ func (lv *LatestVersion) SetLogger(logger *log.Logger) {
 	lv.logger = logger
 }
 
+// SetPolicy sets a policy which is consulted in addition to Policy
+func (lv *LatestVersion) SetPolicy(p policy.Policy) {
+	lv.installerPolicy = p
+}
+
+// SetRegistry sets a registry which is used if Registry is not set
+func (lv *LatestVersion) SetRegistry(reg *registry.Registry) {
+	lv.installerRegistry = reg
+}
+
+func (lv *LatestVersion) registry() *registry.Registry {
+	if lv.Registry != nil {
+		return lv.Registry
+	}
+	return lv.installerRegistry
+}
+
 func (lv *LatestVersion) log() *log.Logger {
 	if lv.logger == nil {
 		return discardLogger
@@ -66,14 +148,49 @@ func (lv *LatestVersion) Validate() error {
 	if !validators.IsProductNameValid(lv.Product.Name) {
 		return fmt.Errorf("invalid product name: %q", lv.Product.Name)
 	}
-	if !validators.IsBinaryNameValid(lv.Product.BinaryName()) {
-		return fmt.Errorf("invalid binary name: %q", lv.Product.BinaryName())
+	// name may depend on the version, which isn't known yet
+	if target := product.CurrentTarget(nil); lv.Product.BinaryNameKnown(target) {
+		binaryName := lv.Product.BinaryNameFor(target)
+		if !validators.IsBinaryNameValid(binaryName) {
+			return fmt.Errorf("invalid binary name: %q", binaryName)
+		}
+	}
+
+	if lv.VerifyVersion {
+		if err := versioncheck.Validate(lv.Product, lv.PreferBuildInfo); err != nil {
+			return err
+		}
+	}
+
+	if err := release.ValidateSigstoreOptions(lv.Sigstore); err != nil {
+		return err
 	}
 
 	return nil
 }
 
+// Install installs the product and records what it created
+// (even if it failed), such that Remove removes only that
 func (lv *LatestVersion) Install(ctx context.Context) (string, error) {
+	firstPath := len(lv.pathsToRemove)
+	execPath, err := lv.install(ctx)
+	items := registry.NewItems(lv.pathsToRemove[firstPath:], lv.backupPaths)
+	lv.removableItems = append(lv.removableItems, versioncheck.RollBack(err, items, lv.log())...)
+	if err != nil {
+		return "", err
+	}
+
+	if reg := lv.registry(); reg != nil {
+		lv.registryEntry, err = reg.RecordInstall(lv.Product.Name, lv.artifact.Version, execPath, items)
+		if err != nil {
+			return "", fmt.Errorf("failed to record install: %w", err)
+		}
+	}
+
+	return execPath, nil
+}
+
+func (lv *LatestVersion) install(ctx context.Context) (string, error) {
 	timeout := defaultTimeout
 	if lv.Timeout > 0 {
 		timeout = lv.Timeout
@@ -81,31 +198,33 @@ func (lv *LatestVersion) Install(ctx context.Context) (string, error) {
 	ctx, cancelFunc := context.WithTimeout(ctx, timeout)
 	defer cancelFunc()
 
-	// TODO: Introduce CheckWithContext to allow for cancellation
-	resp, err := checkpoint.Check(&checkpoint.CheckParams{
-		Product: lv.Product.Name,
-		OS:      runtime.GOOS,
-		Arch:    runtime.GOARCH,
-		Force:   true,
-	})
+	latestVersion, err := lv.latestVersion(ctx)
 	if err != nil {
 		return "", err
 	}
 
-	latestVersion, err := version.NewVersion(resp.CurrentVersion)
+	err = lv.checkPolicy(ctx, latestVersion)
 	if err != nil {
 		return "", err
 	}
 
+	binaryName := lv.Product.BinaryNameFor(product.CurrentTarget(latestVersion))
+	if !validators.IsBinaryNameValid(binaryName) {
+		return "", fmt.Errorf("invalid binary name: %q", binaryName)
+	}
+
 	if lv.pathsToRemove == nil {
 		lv.pathsToRemove = make([]string, 0)
 	}
+	if lv.backupPaths == nil {
+		lv.backupPaths = make(map[string]string)
+	}
 
 	dstDir := lv.InstallDir
 	if dstDir == "" {
 		var err error
 		dirName := fmt.Sprintf("%s_*", lv.Product.Name)
-		dstDir, err = os.MkdirTemp("", dirName)
+		dstDir, err = tempdir.MkdirTemp(dirName)
 		if err != nil {
 			return "", err
 		}
@@ -114,33 +233,28 @@ func (lv *LatestVersion) Install(ctx context.Context) (string, error) {
 	}
 	lv.log().Printf("will install into dir at %s", dstDir)
 
-	rels := rjson.NewReleases()
-	rels.SetLogger(lv.log())
-	pv, err := rels.GetProductVersion(ctx, lv.Product.Name, latestVersion)
+	pv, err := lv.productVersion(ctx, latestVersion)
 	if err != nil {
 		return "", err
 	}
 
-	d := &rjson.Downloader{
-		Logger:           lv.log(),
-		VerifyChecksum:   !lv.SkipChecksumVerification,
-		ArmoredPublicKey: pubkey.DefaultPublicKey,
-		BaseURL:          rels.BaseURL,
-	}
-	if lv.ArmoredPublicKey != "" {
-		d.ArmoredPublicKey = lv.ArmoredPublicKey
-	}
+	d := lv.downloader()
 
 	licenseDir := lv.LicenseDir
 	up, err := d.DownloadAndUnpack(ctx, pv, dstDir, licenseDir)
 	if up != nil {
 		lv.pathsToRemove = append(lv.pathsToRemove, up.PathsToRemove...)
+		// overwritten files are restored by Remove
+		for path, backupPath := range up.BackupPaths {
+			lv.pathsToRemove = append(lv.pathsToRemove, path)
+			lv.backupPaths[path] = backupPath
+		}
 	}
 	if err != nil {
 		return "", err
 	}
 
-	execPath := filepath.Join(dstDir, lv.Product.BinaryName())
+	execPath := filepath.Join(dstDir, binaryName)
 
 	lv.pathsToRemove = append(lv.pathsToRemove, execPath)
 
@@ -150,17 +264,166 @@ func (lv *LatestVersion) Install(ctx context.Context) (string, error) {
 		return "", err
 	}
 
+	if lv.VerifyVersion {
+		err = versioncheck.Check(ctx, lv.log(), lv.Product, lv.PreferBuildInfo, execPath, pv.Version)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	lv.artifact = release.NewArtifact(pv.Version, up, execPath)
+
+	if lv.WriteReceipt {
+		r := receipt.FromUnpacked(lv.Product.Name, pv.Version.String(), up)
+		receiptPath, err := receipt.Write(execPath, r)
+		if err != nil {
+			return "", err
+		}
+		lv.pathsToRemove = append(lv.pathsToRemove, receiptPath)
+		lv.artifact.ExtraFiles = append(lv.artifact.ExtraFiles, receiptPath)
+		lv.log().Printf("wrote install receipt to %s", receiptPath)
+	}
+
 	return execPath, nil
 }
 
+// Artifact returns details of the archive obtained by the last
+// successful Install, or nil if nothing was installed yet
+func (lv *LatestVersion) Artifact() *src.Artifact {
+	return lv.artifact
+}
+
+// Alerts returns alerts published via Checkpoint for the product,
+// as obtained by the last Install
+func (lv *LatestVersion) Alerts() []*Alert {
+	return lv.alerts
+}
+
+// Resolve determines the latest version and the archive
+// which would be installed, without downloading it
+func (lv *LatestVersion) Resolve(ctx context.Context) (*src.Resolution, error) {
+	timeout := defaultTimeout
+	if lv.Timeout > 0 {
+		timeout = lv.Timeout
+	}
+	ctx, cancelFunc := context.WithTimeout(ctx, timeout)
+	defer cancelFunc()
+
+	latestVersion, err := lv.latestVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = lv.checkPolicy(ctx, latestVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	pv, err := lv.productVersion(ctx, latestVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return release.Resolution(lv.downloader(), pv, lv.InstallDir,
+		lv.Product.BinaryNameFor(product.CurrentTarget(pv.Version)))
+}
+
+// latestVersion obtains the latest version from Checkpoint
+// and records any alerts published for the product
+func (lv *LatestVersion) latestVersion(ctx context.Context) (*version.Version, error) {
+	resp, err := check(ctx, lv.httpClient(), lv.log(), lv.checkpointURL(), &checkpoint.CheckParams{
+		Product: lv.Product.Name,
+		OS:      runtime.GOOS,
+		Arch:    runtime.GOARCH,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lv.alerts = alertsFromResponse(resp)
+	for _, alert := range lv.alerts {
+		lv.log().Printf("checkpoint alert (%s): %s %s", alert.Level, alert.Message, alert.URL)
+	}
+
+	return version.NewVersion(resp.CurrentVersion)
+}
+
+// checkPolicy checks that policies allow the version
+// before anything is obtained from the releases site
+func (lv *LatestVersion) checkPolicy(ctx context.Context, v *version.Version) error {
+	return policy.Policies{lv.Policy, lv.installerPolicy}.Check(ctx, &policy.Candidate{
+		Product:           lv.Product.Name,
+		Version:           v,
+		SignatureVerified: !lv.SkipChecksumVerification,
+	})
+}
+
+func (lv *LatestVersion) productVersion(ctx context.Context, v *version.Version) (*rjson.ProductVersion, error) {
+	rels := rjson.NewReleases()
+	if lv.ApiBaseURL != "" {
+		rels.BaseURL = lv.ApiBaseURL
+	}
+	rels.HTTPClient = lv.HTTPClient
+	rels.SetLogger(lv.log())
+	return rels.GetProductVersion(ctx, lv.Product.Name, v)
+}
+
+func (lv *LatestVersion) downloader() *rjson.Downloader {
+	d := &rjson.Downloader{
+		HTTPClient:                  lv.HTTPClient,
+		Logger:                      lv.log(),
+		VerifyChecksum:              !lv.SkipChecksumVerification,
+		ArmoredPublicKey:            pubkey.DefaultPublicKey,
+		BaseURL:                     rjson.NewReleases().BaseURL,
+		AdditionalArmoredPublicKeys: lv.AdditionalArmoredPublicKeys,
+	}
+	if lv.ArmoredPublicKey != "" {
+		d.ArmoredPublicKey = lv.ArmoredPublicKey
+	}
+	if lv.ApiBaseURL != "" {
+		d.BaseURL = lv.ApiBaseURL
+	}
+	d.ChecksumVerifiers = release.ChecksumVerifiers(lv.Sigstore, d.ArmoredPublicKey,
+		lv.AdditionalArmoredPublicKeys, lv.log())
+	return d
+}
+
+func (lv *LatestVersion) checkpointURL() string {
+	if lv.CheckpointURL != "" {
+		return lv.CheckpointURL
+	}
+	return defaultCheckpointURL
+}
+
+func (lv *LatestVersion) httpClient() *http.Client {
+	if lv.HTTPClient != nil {
+		return lv.HTTPClient
+	}
+	return httpclient.NewHTTPClient(lv.log())
+}
+
+// Remove removes files created by Install and restores any files
+// they overwrote. Files which were changed or replaced since
+// they were installed are kept (see registry.RemoveItems).
 func (lv *LatestVersion) Remove(ctx context.Context) error {
-	if lv.pathsToRemove != nil {
-		for _, path := range lv.pathsToRemove {
-			err := os.RemoveAll(path)
-			if err != nil {
-				return err
-			}
+	err := registry.RemoveItems(lv.removableItems, lv.log())
+	if err != nil {
+		return err
+	}
+	lv.removableItems = nil
+
+	if lv.registryEntry != nil {
+		err := lv.registry().Delete(lv.registryEntry)
+		if err != nil {
+			return err
 		}
+		lv.registryEntry = nil
 	}
+
 	return nil
 }
+
+// PlanRemove describes what Remove would do, without removing anything
+func (lv *LatestVersion) PlanRemove(ctx context.Context) (*registry.RemovalPlan, error) {
+	return registry.PlanRemoval(lv.removableItems), nil
+}
@@ -5,13 +5,20 @@ package checkpoint
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-version"
+	hcerrors "github.com/hashicorp/hc-install/errors"
 	"github.com/hashicorp/hc-install/internal/testutil"
+	"github.com/hashicorp/hc-install/policy"
 	"github.com/hashicorp/hc-install/product"
 	"github.com/hashicorp/hc-install/src"
 )
@@ -20,6 +27,7 @@ var (
 	_ src.Installable    = &LatestVersion{}
 	_ src.Removable      = &LatestVersion{}
 	_ src.LoggerSettable = &LatestVersion{}
+	_ src.Resolvable     = &LatestVersion{}
 )
 
 func TestLatestVersion(t *testing.T) {
@@ -66,6 +74,221 @@ func TestLatestVersion(t *testing.T) {
 	}
 }
 
+func TestLatestVersion_mockServers(t *testing.T) {
+	checkpointSrv := newCheckpointServer(t, `{
+	"product": "terraform",
+	"current_version": "0.14.11",
+	"alerts": [
+		{
+			"id": 1,
+			"date": 1619395200,
+			"message": "Security issue in older versions",
+			"url": "https://example.com/alert",
+			"level": "critical"
+		}
+	]
+}`)
+	mockApiRoot := filepath.Join("..", "releases", "testdata", "mock_api_tf_0_14_with_prereleases")
+
+	lv := &LatestVersion{
+		Product:          product.Terraform,
+		ArmoredPublicKey: getTestPubKey(t),
+		CheckpointURL:    checkpointSrv.URL,
+		ApiBaseURL:       testutil.NewTestServer(t, mockApiRoot).URL,
+	}
+	lv.SetLogger(testutil.TestLogger())
+
+	ctx := context.Background()
+
+	execPath, err := lv.Install(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { lv.Remove(ctx) })
+
+	v, err := product.Terraform.GetVersion(ctx, execPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedVersion := version.Must(version.NewVersion("0.14.11"))
+	if !v.Equal(expectedVersion) {
+		t.Fatalf("versions don't match (expected: %s, installed: %s)",
+			expectedVersion, v)
+	}
+
+	alerts := lv.Alerts()
+	if len(alerts) != 1 {
+		t.Fatalf("expected 1 alert, %d given", len(alerts))
+	}
+	if alerts[0].Level != "critical" {
+		t.Fatalf("unexpected alert level: %q", alerts[0].Level)
+	}
+	if !alerts[0].Date.Equal(time.Unix(1619395200, 0)) {
+		t.Fatalf("unexpected alert date: %s", alerts[0].Date)
+	}
+}
+
+func TestLatestVersion_sigstore(t *testing.T) {
+	checkpointSrv := newCheckpointServer(t, `{"product": "terraform", "current_version": "0.14.11"}`)
+	mockApiRoot := filepath.Join("..", "releases", "testdata", "mock_api_tf_0_14_with_prereleases")
+
+	lv := &LatestVersion{
+		Product:          product.Terraform,
+		ArmoredPublicKey: getTestPubKey(t),
+		CheckpointURL:    checkpointSrv.URL,
+		ApiBaseURL:       testutil.NewTestServer(t, mockApiRoot).URL,
+		Sigstore: &src.SigstoreOptions{
+			TrustedRoot:           []byte("{}"),
+			CertificateIdentity:   "https://example.com/release.yml",
+			CertificateOIDCIssuer: "https://example.com",
+		},
+	}
+	lv.SetLogger(testutil.TestLogger())
+
+	ctx := context.Background()
+	t.Cleanup(func() { lv.Remove(ctx) })
+
+	// the mock API publishes no bundle, so the checksums can't be verified
+	_, err := lv.Install(ctx)
+	if err == nil {
+		t.Fatal("expected install to fail without sigstore bundle")
+	}
+	if !strings.Contains(err.Error(), "trusted root") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestLatestVersion_verifyVersion(t *testing.T) {
+	checkpointSrv := newCheckpointServer(t, `{
+	"product": "terraform",
+	"current_version": "0.14.11"
+}`)
+	mockApiRoot := filepath.Join("..", "releases", "testdata", "mock_api_tf_0_14_with_prereleases")
+
+	installDir := t.TempDir()
+	existingPath := filepath.Join(installDir, "terraform")
+	err := os.WriteFile(existingPath, []byte("existing"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// e.g. mislabeled archive served by a mirror
+	p := product.Terraform
+	p.GetVersion = func(context.Context, string) (*version.Version, error) {
+		return version.NewVersion("0.14.10")
+	}
+	lv := &LatestVersion{
+		Product:          p,
+		ArmoredPublicKey: getTestPubKey(t),
+		CheckpointURL:    checkpointSrv.URL,
+		ApiBaseURL:       testutil.NewTestServer(t, mockApiRoot).URL,
+		InstallDir:       installDir,
+		VerifyVersion:    true,
+	}
+	lv.SetLogger(testutil.TestLogger())
+
+	ctx := context.Background()
+	_, err = lv.Install(ctx)
+	var mismatchErr *hcerrors.VersionMismatchError
+	if !errors.As(err, &mismatchErr) {
+		t.Fatalf("expected version mismatch error, got: %v", err)
+	}
+
+	// install is rolled back
+	b, err := os.ReadFile(existingPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "existing" {
+		t.Fatalf("expected overwritten file to be restored, got %d bytes", len(b))
+	}
+	entries, err := os.ReadDir(installDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected only the restored file to be left, found %d entries", len(entries))
+	}
+}
+
+func TestLatestVersion_contextCancelled(t *testing.T) {
+	checkpointSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(checkpointSrv.Close)
+
+	lv := &LatestVersion{
+		Product:       product.Terraform,
+		CheckpointURL: checkpointSrv.URL,
+		Timeout:       100 * time.Millisecond,
+	}
+	lv.SetLogger(testutil.TestLogger())
+
+	_, err := lv.Install(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline to be exceeded, got: %v", err)
+	}
+}
+
+func TestLatestVersion_policyDenied(t *testing.T) {
+	checkpointSrv := newCheckpointServer(t, `{
+	"product": "terraform",
+	"current_version": "0.14.11"
+}`)
+	releasesSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to releases API: %s", r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(releasesSrv.Close)
+
+	lv := &LatestVersion{
+		Product:       product.Terraform,
+		CheckpointURL: checkpointSrv.URL,
+		ApiBaseURL:    releasesSrv.URL,
+		Policy: &policy.DenyVersions{
+			Constraints: []version.Constraints{version.MustConstraints(version.NewConstraint("0.14.11"))},
+			Reason:      "CVE-0000-0000",
+		},
+	}
+	lv.SetLogger(testutil.TestLogger())
+
+	ctx := context.Background()
+
+	_, err := lv.Resolve(ctx)
+	if !policy.IsDenied(err) {
+		t.Fatalf("expected resolution to be denied by policy, got: %v", err)
+	}
+
+	_, err = lv.Install(ctx)
+	if !policy.IsDenied(err) {
+		t.Fatalf("expected installation to be denied by policy, got: %v", err)
+	}
+	if len(lv.pathsToRemove) != 0 {
+		t.Fatalf("expected no paths to be created, got: %q", lv.pathsToRemove)
+	}
+}
+
+func newCheckpointServer(t *testing.T, response string) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/check/terraform" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, response)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func getTestPubKey(t testing.TB) string {
+	b, err := os.ReadFile(filepath.Join("..", "releases", "testdata", "2FCA0A85.pub"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
 func TestLatestVersionValidate(t *testing.T) {
 	t.Parallel()
 
@@ -96,6 +319,15 @@ func TestLatestVersionValidate(t *testing.T) {
 				Product: product.Terraform,
 			},
 		},
+		"Sigstore-without-identity": {
+			lv: LatestVersion{
+				Product: product.Terraform,
+				Sigstore: &src.SigstoreOptions{
+					TrustedRoot: []byte("{}"),
+				},
+			},
+			expectedErr: fmt.Errorf("CertificateIdentity and CertificateOIDCIssuer must be provided for Sigstore verification"),
+		},
 	}
 
 	for name, testCase := range testCases {
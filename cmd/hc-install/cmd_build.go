@@ -0,0 +1,180 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/cli"
+
+	hci "github.com/hashicorp/hc-install"
+	"github.com/hashicorp/hc-install/build"
+	"github.com/hashicorp/hc-install/src"
+)
+
+type BuildCommand struct {
+	Ui cli.Ui
+
+	// JSON enables machine-readable output
+	// (set via global -json flag)
+	JSON bool
+}
+
+func (c *BuildCommand) Name() string { return "build" }
+
+func (c *BuildCommand) Synopsis() string {
+	return "Build a HashiCorp product from a git reference"
+}
+
+func (c *BuildCommand) Help() string {
+	helpText := `
+Usage: hc-install build [options] <product>
+
+  This command clones the source code of a HashiCorp product
+  and builds it from the given git reference.
+  Options:
+    -ref           Git reference to build, e.g. refs/heads/main
+                   or refs/tags/v1.5.0. Defaults to HEAD.
+    -repo-url      URL of the git repository to clone instead
+                   of the official one.
+    -clone-timeout Timeout for cloning the repository, e.g. 10m.
+    -build-timeout Timeout for building the product, e.g. 30m.
+    -path          Path to directory where the product will be built.
+                   Defaults to current working directory.
+    -log-file      Path to file where logs will be written. /dev/stdout
+                   or /dev/stderr can be used to log to STDOUT/STDERR.
+    -json          Print the result as a JSON object.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *BuildCommand) Run(args []string) int {
+	var (
+		ref            string
+		repoURL        string
+		cloneTimeout   time.Duration
+		buildTimeout   time.Duration
+		installDirPath string
+		logFilePath    string
+	)
+
+	fs := flag.NewFlagSet("build", flag.ContinueOnError)
+	fs.Usage = func() { c.Ui.Output(c.Help()) }
+	if c.JSON {
+		fs.SetOutput(io.Discard)
+		fs.Usage = func() {}
+	}
+	fs.StringVar(&ref, "ref", "", "git reference to build")
+	fs.StringVar(&repoURL, "repo-url", "", "URL of the git repository to clone")
+	fs.DurationVar(&cloneTimeout, "clone-timeout", 0, "timeout for cloning the repository")
+	fs.DurationVar(&buildTimeout, "build-timeout", 0, "timeout for building the product")
+	fs.StringVar(&installDirPath, "path", "", "path to directory where the product will be built")
+	fs.StringVar(&logFilePath, "log-file", "", "path to file where logs will be written")
+	fs.BoolVar(&c.JSON, "json", c.JSON, "print the result as a JSON object")
+
+	err := fs.Parse(args)
+	out := newOutput(c.Ui, c.JSON)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			// help was requested, which is not an error
+			return 0
+		}
+		if !c.JSON {
+			// the error was already reported by the flag parser
+			return 1
+		}
+		return out.Fail(errCodeUsage, err, nil)
+	}
+
+	// golang's arg parser is Posix-compliant but doesn't match the
+	// common GNU flag parsing argument, so force an error rather than
+	// silently dropping the options
+	args = fs.Args()
+	if len(args) != 1 {
+		return out.Fail(errCodeUsage, fmt.Errorf(`This command requires one positional argument: <product>
+Option flags must be provided before the positional argument`), nil)
+	}
+	productName := fs.Args()[0]
+	result := &commandResult{
+		Product: productName,
+		Ref:     refOrHead(ref),
+	}
+
+	if installDirPath == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return out.Fail(errCodeInvalidPath, fmt.Errorf("Could not get current working directory for default installation path: %w", err), result)
+		}
+		installDirPath = cwd
+	}
+
+	logger, err := newLogger(logFilePath)
+	if err != nil {
+		return out.Fail(errCodeLogSetup, err, result)
+	}
+
+	gr, err := c.gitRevision(productName, ref, repoURL, installDirPath)
+	if err != nil {
+		return out.Fail(errCodeUnknownProduct, err, result)
+	}
+	gr.CloneTimeout = cloneTimeout
+	gr.BuildTimeout = buildTimeout
+
+	out.Info(fmt.Sprintf("hc-install: will build %s@%s from %s",
+		productName, refOrHead(ref), gr.Product.BuildInstructions.GitRepoURL))
+
+	builtPath, err := c.build(gr, logger)
+	result.Revision = gr.Revision()
+	if err != nil {
+		msg := fmt.Errorf("failed to build %s@%s: %w", productName, refOrHead(ref), err)
+		return out.Fail(errCodeBuildFailed, msg, result)
+	}
+
+	result.Path = builtPath
+	return out.Success(fmt.Sprintf("built %s@%s (%s) to %s",
+		productName, refOrHead(ref), gr.Revision(), builtPath), result)
+}
+
+func (c *BuildCommand) gitRevision(productName, ref, repoURL, installDirPath string) (*build.GitRevision, error) {
+	p, err := knownProduct(productName)
+	if err != nil {
+		return nil, err
+	}
+
+	if repoURL != "" {
+		// copy instructions to avoid mutating the package-level product
+		bi := *p.BuildInstructions
+		bi.GitRepoURL = repoURL
+		p.BuildInstructions = &bi
+	}
+
+	return &build.GitRevision{
+		Product:    p,
+		Ref:        ref,
+		InstallDir: installDirPath,
+	}, nil
+}
+
+func (c *BuildCommand) build(gr *build.GitRevision, logger *log.Logger) (string, error) {
+	i := hci.NewInstaller()
+	i.SetLogger(logger)
+
+	ctx := context.Background()
+	return i.Ensure(ctx, []src.Source{gr})
+}
+
+func refOrHead(ref string) string {
+	if ref == "" {
+		return "HEAD"
+	}
+	return ref
+}
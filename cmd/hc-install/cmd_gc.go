@@ -0,0 +1,163 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hashicorp/cli"
+
+	"github.com/hashicorp/hc-install/gc"
+)
+
+type GCCommand struct {
+	Ui cli.Ui
+
+	// JSON enables machine-readable output
+	// (set via global -json flag)
+	JSON bool
+}
+
+func (c *GCCommand) Name() string { return "gc" }
+
+func (c *GCCommand) Synopsis() string {
+	return "Remove stale temporary directories left behind by hc-install"
+}
+
+func (c *GCCommand) Help() string {
+	helpText := `
+Usage: hc-install gc [options]
+
+  This command finds temporary directories created by hc-install
+  and removes those which are older than the given age and no longer
+  in use by any process (e.g. after a crash).
+  Options:
+    -dir      Directory to look for temporary directories in.
+              Defaults to the default directory for temporary files.
+    -min-age  How old a directory has to be to be removed. Defaults to 24h.
+    -dry-run  Only report directories which would be removed.
+    -log-file Path to file where logs will be written. /dev/stdout
+              or /dev/stderr can be used to log to STDOUT/STDERR.
+    -json     Print the result as a JSON object.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *GCCommand) Run(args []string) int {
+	var (
+		dir         string
+		minAge      time.Duration
+		dryRun      bool
+		logFilePath string
+	)
+
+	fs := flag.NewFlagSet("gc", flag.ContinueOnError)
+	fs.Usage = func() { c.Ui.Output(c.Help()) }
+	if c.JSON {
+		fs.SetOutput(io.Discard)
+		fs.Usage = func() {}
+	}
+	fs.StringVar(&dir, "dir", "", "directory to look for temporary directories in")
+	fs.DurationVar(&minAge, "min-age", gc.DefaultMinAge, "how old a directory has to be to be removed")
+	fs.BoolVar(&dryRun, "dry-run", false, "only report directories which would be removed")
+	fs.StringVar(&logFilePath, "log-file", "", "path to file where logs will be written")
+	fs.BoolVar(&c.JSON, "json", c.JSON, "print the result as a JSON object")
+
+	err := fs.Parse(args)
+	out := newOutput(c.Ui, c.JSON)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			// help was requested, which is not an error
+			return 0
+		}
+		if !c.JSON {
+			// the error was already reported by the flag parser
+			return 1
+		}
+		return out.Fail(errCodeUsage, err, nil)
+	}
+
+	result := &commandResult{DryRun: dryRun}
+
+	if fs.NArg() != 0 {
+		return out.Fail(errCodeUsage, fmt.Errorf("gc does not take any arguments"), result)
+	}
+	if minAge <= 0 {
+		return out.Fail(errCodeUsage, fmt.Errorf("-min-age must be positive"), result)
+	}
+
+	logger, err := newLogger(logFilePath)
+	if err != nil {
+		return out.Fail(errCodeLogSetup, err, result)
+	}
+
+	collector := &gc.Collector{
+		Dir:    dir,
+		MinAge: minAge,
+		DryRun: dryRun,
+	}
+	collector.SetLogger(logger)
+
+	entries, err := collector.Collect(context.Background())
+	if err != nil {
+		return out.Fail(errCodeGCFailed, fmt.Errorf("failed to collect temporary directories: %w", err), result)
+	}
+
+	failed := 0
+	result.TempDirs = make([]*tempDirResult, 0, len(entries))
+	for _, e := range entries {
+		td := &tempDirResult{
+			Path:      e.Path,
+			CreatedAt: e.CreatedAt.Format(time.RFC3339),
+			PID:       e.PID,
+			Hostname:  e.Hostname,
+			SizeBytes: e.Size,
+			Locked:    e.Locked,
+			Stale:     e.Stale,
+			Removed:   e.Removed,
+		}
+		if e.Err != nil {
+			td.Error = e.Err.Error()
+			failed++
+		}
+		result.TempDirs = append(result.TempDirs, td)
+	}
+
+	if failed > 0 {
+		return out.Fail(errCodeGCFailed, fmt.Errorf("failed to remove %d temporary directories", failed), result)
+	}
+	if len(entries) == 0 {
+		return out.Success("no temporary directories found", result)
+	}
+	return out.Success(tempDirsTable(result.TempDirs, dryRun), result)
+}
+
+// tempDirsTable formats temporary directories as a human-readable table
+func tempDirsTable(tempDirs []*tempDirResult, dryRun bool) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STATUS\tCREATED\tPID\tSIZE\tPATH")
+	for _, td := range tempDirs {
+		status := "kept"
+		switch {
+		case td.Locked:
+			status = "locked"
+		case td.Removed:
+			status = "removed"
+		case td.Stale && dryRun:
+			status = "stale"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\n", status, td.CreatedAt, td.PID, td.SizeBytes, td.Path)
+	}
+	w.Flush()
+	return strings.TrimSpace(buf.String())
+}
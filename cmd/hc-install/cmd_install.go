@@ -5,25 +5,31 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"log"
 	"os"
-	"runtime"
 	"strings"
 
 	"github.com/hashicorp/cli"
 	"github.com/hashicorp/go-version"
 
 	hci "github.com/hashicorp/hc-install"
-	"github.com/hashicorp/hc-install/product"
+	"github.com/hashicorp/hc-install/policy"
+	"github.com/hashicorp/hc-install/receipt"
+	"github.com/hashicorp/hc-install/registry"
 	"github.com/hashicorp/hc-install/releases"
 	"github.com/hashicorp/hc-install/src"
 )
 
 type InstallCommand struct {
 	Ui cli.Ui
+
+	// JSON enables machine-readable output
+	// (set via global -json flag)
+	JSON bool
 }
 
 func (c *InstallCommand) Name() string { return "install" }
@@ -43,25 +49,68 @@ Usage: hc-install install [options] -version <version> <product>
               Defaults to current working directory.
     -log-file Path to file where logs will be written. /dev/stdout
               or /dev/stderr can be used to log to STDOUT/STDERR.
+    -dry-run  Resolve the release which would be installed
+              without downloading or installing anything.
+    -policy-file
+              Path to JSON file with policy rules the version
+              has to be allowed by, e.g. to deny known vulnerable versions.
+    -receipt  Write an install receipt recording the provenance
+              of the binary next to it (<binary>.hc-install.json).
+    -verify-version
+              Verify that the installed binary reports the requested version,
+              e.g. to detect mislabeled archives served by a mirror.
+    -record   Record installed files in the install registry, such that
+              they can be removed via the uninstall command.
+    -registry-dir
+              Path to directory of the install registry.
+              Defaults to hc-install/registry in the user config directory.
+    -json     Print the result as a JSON object.
 `
 	return strings.TrimSpace(helpText)
 }
 
 func (c *InstallCommand) Run(args []string) int {
 	var (
-		version        string
+		rawVersion     string
 		installDirPath string
 		logFilePath    string
+		dryRun         bool
+		policyFilePath string
+		writeReceipt   bool
+		verifyVersion  bool
+		record         bool
+		registryDir    string
 	)
 
-	fs := flag.NewFlagSet("install", flag.ExitOnError)
+	fs := flag.NewFlagSet("install", flag.ContinueOnError)
 	fs.Usage = func() { c.Ui.Output(c.Help()) }
-	fs.StringVar(&version, "version", "", "version of product to install")
+	if c.JSON {
+		fs.SetOutput(io.Discard)
+		fs.Usage = func() {}
+	}
+	fs.StringVar(&rawVersion, "version", "", "version of product to install")
 	fs.StringVar(&installDirPath, "path", "", "path to directory where production will be installed")
 	fs.StringVar(&logFilePath, "log-file", "", "path to file where logs will be written")
-
-	if err := fs.Parse(args); err != nil {
-		return 1
+	fs.BoolVar(&dryRun, "dry-run", false, "resolve the release without installing it")
+	fs.StringVar(&policyFilePath, "policy-file", "", "path to JSON file with policy rules")
+	fs.BoolVar(&writeReceipt, "receipt", false, "write an install receipt next to the binary")
+	fs.BoolVar(&verifyVersion, "verify-version", false, "verify that the binary reports the requested version")
+	fs.BoolVar(&record, "record", false, "record installed files in the install registry")
+	fs.StringVar(&registryDir, "registry-dir", "", "path to directory of the install registry")
+	fs.BoolVar(&c.JSON, "json", c.JSON, "print the result as a JSON object")
+
+	err := fs.Parse(args)
+	out := newOutput(c.Ui, c.JSON)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			// help was requested, which is not an error
+			return 0
+		}
+		if !c.JSON {
+			// the error was already reported by the flag parser
+			return 1
+		}
+		return out.Fail(errCodeUsage, err, nil)
 	}
 
 	// golang's arg parser is Posix-compliant but doesn't match the
@@ -69,72 +118,132 @@ func (c *InstallCommand) Run(args []string) int {
 	// silently dropping the options
 	args = fs.Args()
 	if len(args) != 1 {
-		c.Ui.Error(`This command requires one positional argument: <product>
-Option flags must be provided before the positional argument`)
-		return 1
+		return out.Fail(errCodeUsage, fmt.Errorf(`This command requires one positional argument: <product>
+Option flags must be provided before the positional argument`), nil)
 	}
 	product := fs.Args()[0]
+	result := &commandResult{Product: product}
 
-	if version == "" {
-		c.Ui.Error("-version flag is required")
-		return 1
+	if rawVersion == "" {
+		return out.Fail(errCodeUsage, fmt.Errorf("-version flag is required"), result)
 	}
 
 	if installDirPath == "" {
 		cwd, err := os.Getwd()
 		if err != nil {
-			c.Ui.Error(fmt.Sprintf("Could not get current working directory for default installation path: %v", err))
-			return 1
+			return out.Fail(errCodeInvalidPath, fmt.Errorf("Could not get current working directory for default installation path: %w", err), result)
 		}
 		installDirPath = cwd
 	}
 
-	logger := log.New(io.Discard, "", 0)
-	if logFilePath != "" {
-		f, err := os.OpenFile(logFilePath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	logger, err := newLogger(logFilePath)
+	if err != nil {
+		return out.Fail(errCodeLogSetup, err, result)
+	}
+
+	if dryRun {
+		out.Info(fmt.Sprintf("hc-install: will resolve %s@%s", product, rawVersion))
+	} else {
+		out.Info(fmt.Sprintf("hc-install: will install %s@%s", product, rawVersion))
+	}
+
+	v, err := version.NewVersion(rawVersion)
+	if err != nil {
+		return out.Fail(errCodeInvalidVersion, fmt.Errorf("invalid version: %w", err), result)
+	}
+
+	var p policy.Policy
+	if policyFilePath != "" {
+		p, err = policy.LoadFile(policyFilePath)
 		if err != nil {
-			c.Ui.Error(fmt.Sprintf("unable to log into %q: %s", logFilePath, err))
-			return 1
+			return out.Fail(errCodeUsage, err, result)
 		}
-		logger = log.New(f, "[DEBUG] ", log.LstdFlags|log.Lshortfile|log.Lmicroseconds)
 	}
 
-	installedPath, err := c.install(product, version, installDirPath, logger)
+	if dryRun {
+		plan, err := c.plan(product, v, installDirPath, p, logger)
+		if err != nil {
+			msg := fmt.Errorf("failed to resolve %s@%s: %w", product, rawVersion, err)
+			return out.Fail(errCodeInstallFailed, msg, result)
+		}
+
+		result.DryRun = true
+		result.Path = plan.Resolution.ExecPath
+		result.Version = plan.Resolution.Version.String()
+		result.ArchiveURL = plan.Resolution.ArchiveURL
+
+		return out.Success(fmt.Sprintf("would install %s@%s from %s to %s",
+			product, result.Version, result.ArchiveURL, result.Path), result)
+	}
+
+	var reg *registry.Registry
+	if record {
+		reg, err = newRegistry(registryDir, logger)
+		if err != nil {
+			return out.Fail(errCodeInvalidPath, err, result)
+		}
+	}
+
+	ir, err := c.install(product, v, installDirPath, p, writeReceipt, verifyVersion, reg, logger)
 	if err != nil {
-		msg := fmt.Sprintf("failed to install %s@%s: %v", product, version, err)
-		c.Ui.Error(msg)
-		return 1
+		msg := fmt.Errorf("failed to install %s@%s: %w", product, rawVersion, err)
+		return out.Fail(errCodeInstallFailed, msg, result)
 	}
 
-	c.Ui.Info(fmt.Sprintf("installed %s@%s to %s", product, version, installedPath))
-	return 0
+	result.Path = ir.ExecPath
+	result.Version = v.String()
+	if ir.Version != nil {
+		result.Version = ir.Version.String()
+	}
+	result.SHA256 = ir.SHA256
+	result.SigningKeyID = ir.SigningKeyID
+	result.LicensePaths = ir.LicensePaths
+	if writeReceipt {
+		result.ReceiptPath = receipt.Path(ir.ExecPath)
+	}
+
+	return out.Success(fmt.Sprintf("installed %s@%s to %s", product, rawVersion, ir.ExecPath), result)
 }
 
-func (c *InstallCommand) install(project, tag, installDirPath string, logger *log.Logger) (string, error) {
-	msg := fmt.Sprintf("hc-install: will install %s@%s", project, tag)
-	c.Ui.Info(msg)
+func (c *InstallCommand) install(project string, v *version.Version, installDirPath string, p policy.Policy, writeReceipt, verifyVersion bool, reg *registry.Registry, logger *log.Logger) (*hci.InstallResult, error) {
+	i := hci.NewInstaller()
+	i.SetLogger(logger)
+	i.SetPolicy(p)
+	i.SetRegistry(reg)
+
+	prod := namedProduct(project)
+	if verifyVersion {
+		// only known products come with a version getter
+		var err error
+		prod, err = knownProduct(project)
+		if err != nil {
+			return nil, fmt.Errorf("unable to verify version: %w", err)
+		}
+	}
 
-	v, err := version.NewVersion(tag)
-	if err != nil {
-		return "", fmt.Errorf("invalid version: %w", err)
+	source := &releases.ExactVersion{
+		Product:       prod,
+		Version:       v,
+		InstallDir:    installDirPath,
+		WriteReceipt:  writeReceipt,
+		VerifyVersion: verifyVersion,
 	}
+
+	ctx := context.Background()
+	return i.InstallWithResult(ctx, []src.Installable{source})
+}
+
+func (c *InstallCommand) plan(project string, v *version.Version, installDirPath string, p policy.Policy, logger *log.Logger) (*hci.InstallPlan, error) {
 	i := hci.NewInstaller()
 	i.SetLogger(logger)
+	i.SetPolicy(p)
 
 	source := &releases.ExactVersion{
-		Product: product.Product{
-			Name: project,
-			BinaryName: func() string {
-				if runtime.GOOS == "windows" {
-					return fmt.Sprintf("%s.exe", project)
-				}
-				return project
-			},
-		},
+		Product:    namedProduct(project),
 		Version:    v,
 		InstallDir: installDirPath,
 	}
 
 	ctx := context.Background()
-	return i.Install(ctx, []src.Installable{source})
+	return i.Plan(ctx, []src.Source{source})
 }
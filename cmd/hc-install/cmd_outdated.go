@@ -0,0 +1,175 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/go-version"
+
+	"github.com/hashicorp/hc-install/outdated"
+	"github.com/hashicorp/hc-install/policy"
+	"github.com/hashicorp/hc-install/product"
+)
+
+type OutdatedCommand struct {
+	Ui cli.Ui
+
+	// JSON enables machine-readable output
+	// (set via global -json flag)
+	JSON bool
+}
+
+func (c *OutdatedCommand) Name() string { return "outdated" }
+
+func (c *OutdatedCommand) Synopsis() string {
+	return "Report installed products with newer versions available"
+}
+
+func (c *OutdatedCommand) Help() string {
+	helpText := `
+Usage: hc-install outdated [options] [<product>...]
+
+  This command finds installed binaries of the given products
+  (or all known products) in $PATH and reports their versions
+  along with the latest versions available. Nothing is installed.
+  Options:
+    -scope    Which newer versions are considered updates: latest (default),
+              minor (same major version) or patch (same major.minor version).
+    -discover Also find binaries installed via tfenv, asdf, mise,
+              Homebrew or previously installed by hc-install.
+    -policy-file
+              Path to JSON file with policy rules newer versions
+              have to be allowed by to be considered updates.
+    -log-file Path to file where logs will be written. /dev/stdout
+              or /dev/stderr can be used to log to STDOUT/STDERR.
+    -json     Print the result as a JSON object.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OutdatedCommand) Run(args []string) int {
+	var (
+		scope          string
+		discover       bool
+		policyFilePath string
+		logFilePath    string
+	)
+
+	fs := flag.NewFlagSet("outdated", flag.ContinueOnError)
+	fs.Usage = func() { c.Ui.Output(c.Help()) }
+	if c.JSON {
+		fs.SetOutput(io.Discard)
+		fs.Usage = func() {}
+	}
+	fs.StringVar(&scope, "scope", string(outdated.ScopeLatest), "which newer versions are considered updates")
+	fs.BoolVar(&discover, "discover", false, "also find binaries installed via version managers")
+	fs.StringVar(&policyFilePath, "policy-file", "", "path to JSON file with policy rules")
+	fs.StringVar(&logFilePath, "log-file", "", "path to file where logs will be written")
+	fs.BoolVar(&c.JSON, "json", c.JSON, "print the result as a JSON object")
+
+	err := fs.Parse(args)
+	out := newOutput(c.Ui, c.JSON)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			// help was requested, which is not an error
+			return 0
+		}
+		if !c.JSON {
+			// the error was already reported by the flag parser
+			return 1
+		}
+		return out.Fail(errCodeUsage, err, nil)
+	}
+
+	result := &commandResult{}
+
+	products := make([]product.Product, 0)
+	for _, name := range fs.Args() {
+		p, err := knownProduct(name)
+		if err != nil {
+			return out.Fail(errCodeUnknownProduct, err, result)
+		}
+		products = append(products, p)
+	}
+
+	logger, err := newLogger(logFilePath)
+	if err != nil {
+		return out.Fail(errCodeLogSetup, err, result)
+	}
+
+	checker := &outdated.Checker{
+		Products:                products,
+		Scope:                   outdated.Scope(scope),
+		DiscoverManagedInstalls: discover,
+	}
+	checker.SetLogger(logger)
+	if err := checker.Validate(); err != nil {
+		return out.Fail(errCodeUsage, err, result)
+	}
+	if policyFilePath != "" {
+		p, err := policy.LoadFile(policyFilePath)
+		if err != nil {
+			return out.Fail(errCodeUsage, err, result)
+		}
+		checker.Policy = p
+	}
+
+	reports, err := checker.Check(context.Background())
+	if err != nil {
+		return out.Fail(errCodeCheckFailed, fmt.Errorf("failed to check versions: %w", err), result)
+	}
+
+	result.Installations = make([]*installationResult, 0, len(reports))
+	for _, r := range reports {
+		result.Installations = append(result.Installations, &installationResult{
+			Product:       r.Product,
+			Path:          r.ExecPath,
+			Version:       r.InstalledVersion.String(),
+			LatestVersion: versionString(r.LatestVersion),
+			UpdateVersion: versionString(r.UpdateVersion),
+			Outdated:      r.Outdated(),
+		})
+	}
+
+	if len(reports) == 0 {
+		return out.Success("no installed products found", result)
+	}
+	return out.Success(installationsTable(result.Installations), result)
+}
+
+// installationsTable formats installations as a human-readable table
+func installationsTable(installations []*installationResult) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PRODUCT\tINSTALLED\tLATEST\tUPDATE\tPATH")
+	for _, i := range installations {
+		update := i.UpdateVersion
+		if update == "" {
+			update = "-"
+		}
+		latest := i.LatestVersion
+		if latest == "" {
+			latest = "unknown"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", i.Product, i.Version, latest, update, i.Path)
+	}
+	w.Flush()
+	return strings.TrimSpace(buf.String())
+}
+
+func versionString(v *version.Version) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
@@ -0,0 +1,176 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/go-version"
+
+	"github.com/hashicorp/hc-install/registry"
+)
+
+type UninstallCommand struct {
+	Ui cli.Ui
+
+	// JSON enables machine-readable output
+	// (set via global -json flag)
+	JSON bool
+}
+
+func (c *UninstallCommand) Name() string { return "uninstall" }
+
+func (c *UninstallCommand) Synopsis() string {
+	return "Remove a previously recorded install of a HashiCorp product"
+}
+
+func (c *UninstallCommand) Help() string {
+	helpText := `
+Usage: hc-install uninstall [options] <product> <version>
+
+  This command removes all files created by installs of the given
+  product version, as recorded in the install registry
+  (see the -record option of the install command).
+  Files which were changed or replaced since they were installed are kept.
+  Files which were overwritten by the install are restored.
+  Options:
+    -dry-run  List files which would be removed or restored
+              without changing anything.
+    -registry-dir
+              Path to directory of the install registry.
+              Defaults to hc-install/registry in the user config directory.
+    -log-file Path to file where logs will be written. /dev/stdout
+              or /dev/stderr can be used to log to STDOUT/STDERR.
+    -json     Print the result as a JSON object.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *UninstallCommand) Run(args []string) int {
+	var (
+		dryRun      bool
+		registryDir string
+		logFilePath string
+	)
+
+	fs := flag.NewFlagSet("uninstall", flag.ContinueOnError)
+	fs.Usage = func() { c.Ui.Output(c.Help()) }
+	if c.JSON {
+		fs.SetOutput(io.Discard)
+		fs.Usage = func() {}
+	}
+	fs.BoolVar(&dryRun, "dry-run", false, "list files which would be removed without removing them")
+	fs.StringVar(&registryDir, "registry-dir", "", "path to directory of the install registry")
+	fs.StringVar(&logFilePath, "log-file", "", "path to file where logs will be written")
+	fs.BoolVar(&c.JSON, "json", c.JSON, "print the result as a JSON object")
+
+	err := fs.Parse(args)
+	out := newOutput(c.Ui, c.JSON)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			// help was requested, which is not an error
+			return 0
+		}
+		if !c.JSON {
+			// the error was already reported by the flag parser
+			return 1
+		}
+		return out.Fail(errCodeUsage, err, nil)
+	}
+
+	args = fs.Args()
+	if len(args) != 2 {
+		return out.Fail(errCodeUsage, fmt.Errorf(`This command requires two positional arguments: <product> <version>
+Option flags must be provided before the positional arguments`), nil)
+	}
+	product, rawVersion := args[0], args[1]
+	result := &commandResult{Product: product, Version: rawVersion}
+
+	v, err := version.NewVersion(rawVersion)
+	if err != nil {
+		return out.Fail(errCodeInvalidVersion, fmt.Errorf("invalid version: %w", err), result)
+	}
+	result.Version = v.String()
+
+	logger, err := newLogger(logFilePath)
+	if err != nil {
+		return out.Fail(errCodeLogSetup, err, result)
+	}
+
+	reg, err := newRegistry(registryDir, logger)
+	if err != nil {
+		return out.Fail(errCodeInvalidPath, err, result)
+	}
+
+	plan, err := reg.PlanRemove(product, v)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotFound) {
+			return out.Fail(errCodeNotInstalled, err, result)
+		}
+		return out.Fail(errCodeRemoveFailed, err, result)
+	}
+	result.RemovedPaths = plan.Remove
+	result.RestoredPaths = plan.Restore
+	for _, kp := range plan.Keep {
+		result.KeptPaths = append(result.KeptPaths, kp.Path)
+	}
+
+	if dryRun {
+		result.DryRun = true
+		return out.Success(removalPlanSummary(plan), result)
+	}
+
+	removed, err := reg.Remove(context.Background(), product, v)
+	if err != nil {
+		msg := fmt.Errorf("failed to uninstall %s@%s: %w", product, v, err)
+		return out.Fail(errCodeRemoveFailed, msg, result)
+	}
+
+	paths := make([]string, 0, len(removed))
+	for _, e := range removed {
+		paths = append(paths, e.ExecPath)
+	}
+	return out.Success(fmt.Sprintf("uninstalled %s@%s from %s",
+		product, v, strings.Join(paths, ", ")), result)
+}
+
+// removalPlanSummary formats the plan as a human-readable list
+func removalPlanSummary(plan *registry.RemovalPlan) string {
+	lines := make([]string, 0)
+	for _, path := range plan.Remove {
+		lines = append(lines, "would remove "+path)
+	}
+	for _, path := range plan.Restore {
+		lines = append(lines, "would restore "+path)
+	}
+	for _, kp := range plan.Keep {
+		lines = append(lines, fmt.Sprintf("would keep %s: %s", kp.Path, kp.Err))
+	}
+	if len(lines) == 0 {
+		return "nothing to remove"
+	}
+	return strings.Join(lines, "\n")
+}
+
+// newRegistry returns the install registry in the given directory,
+// or in the default one if empty
+func newRegistry(dir string, logger *log.Logger) (*registry.Registry, error) {
+	if dir == "" {
+		var err error
+		dir, err = registry.DefaultDir()
+		if err != nil {
+			return nil, fmt.Errorf("unable to determine registry directory: %w", err)
+		}
+	}
+	reg := &registry.Registry{Dir: dir}
+	reg.SetLogger(logger)
+	return reg, nil
+}
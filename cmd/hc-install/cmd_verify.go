@@ -0,0 +1,161 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/go-version"
+
+	"github.com/hashicorp/hc-install/releases"
+)
+
+type VerifyCommand struct {
+	Ui cli.Ui
+
+	// JSON enables machine-readable output
+	// (set via global -json flag)
+	JSON bool
+}
+
+func (c *VerifyCommand) Name() string { return "verify" }
+
+func (c *VerifyCommand) Synopsis() string {
+	return "Verify a local file against an official release"
+}
+
+func (c *VerifyCommand) Help() string {
+	helpText := `
+Usage: hc-install verify [options] <product> <path>
+
+  This command verifies that a local ZIP archive or an unpacked binary
+  matches an official release, using the signed release checksums.
+  Nothing is installed.
+
+  Files with the .zip extension are verified as archives,
+  any other files are verified as unpacked binaries.
+  Options:
+    -version  Version of the release to verify against. Required for
+              binaries, optional for archives named as on the releases site.
+    -os       OS of the binary (defaults to current OS).
+    -arch     Architecture of the binary (defaults to current architecture).
+    -log-file Path to file where logs will be written. /dev/stdout
+              or /dev/stderr can be used to log to STDOUT/STDERR.
+    -json     Print the result as a JSON object.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VerifyCommand) Run(args []string) int {
+	var (
+		rawVersion  string
+		goos        string
+		goarch      string
+		logFilePath string
+	)
+
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	fs.Usage = func() { c.Ui.Output(c.Help()) }
+	if c.JSON {
+		fs.SetOutput(io.Discard)
+		fs.Usage = func() {}
+	}
+	fs.StringVar(&rawVersion, "version", "", "version of the release to verify against")
+	fs.StringVar(&goos, "os", "", "OS of the binary")
+	fs.StringVar(&goarch, "arch", "", "architecture of the binary")
+	fs.StringVar(&logFilePath, "log-file", "", "path to file where logs will be written")
+	fs.BoolVar(&c.JSON, "json", c.JSON, "print the result as a JSON object")
+
+	err := fs.Parse(args)
+	out := newOutput(c.Ui, c.JSON)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			// help was requested, which is not an error
+			return 0
+		}
+		if !c.JSON {
+			// the error was already reported by the flag parser
+			return 1
+		}
+		return out.Fail(errCodeUsage, err, nil)
+	}
+
+	// golang's arg parser is Posix-compliant but doesn't match the
+	// common GNU flag parsing argument, so force an error rather than
+	// silently dropping the options
+	args = fs.Args()
+	if len(args) != 2 {
+		return out.Fail(errCodeUsage, fmt.Errorf(`This command requires two positional arguments: <product> <path>
+Option flags must be provided before the positional arguments`), nil)
+	}
+	productName, path := args[0], args[1]
+	result := &commandResult{
+		Product: productName,
+		Version: rawVersion,
+		Path:    path,
+	}
+
+	logger, err := newLogger(logFilePath)
+	if err != nil {
+		return out.Fail(errCodeLogSetup, err, result)
+	}
+
+	verifier := &releases.Verifier{
+		Product: namedProduct(productName),
+		OS:      goos,
+		Arch:    goarch,
+	}
+	if rawVersion != "" {
+		v, err := version.NewVersion(rawVersion)
+		if err != nil {
+			return out.Fail(errCodeInvalidVersion, fmt.Errorf("invalid version: %w", err), result)
+		}
+		verifier.Version = v
+	}
+
+	isArchive := strings.EqualFold(filepath.Ext(path), ".zip")
+	if isArchive {
+		out.Info(fmt.Sprintf("hc-install: will verify archive %s", path))
+	} else {
+		if verifier.Version == nil {
+			return out.Fail(errCodeUsage, fmt.Errorf("-version flag is required to verify binaries"), result)
+		}
+		out.Info(fmt.Sprintf("hc-install: will verify binary %s", path))
+	}
+
+	verification, err := c.verify(verifier, path, isArchive, logger)
+	if err != nil {
+		code := errCodeVerifyFailed
+		if errors.Is(err, releases.ErrNoMatchingRelease) {
+			code = errCodeNoMatchingRelease
+		}
+		return out.Fail(code, fmt.Errorf("failed to verify %s: %w", path, err), result)
+	}
+
+	result.Version = verification.Version.String()
+	result.SHA256 = verification.SHA256
+	result.SigningKeyID = verification.SigningKeyID
+	result.MatchedFile = verification.Filename
+
+	return out.Success(fmt.Sprintf("verified %s matches %q of %s@%s (signed by %s)",
+		path, verification.Filename, productName, verification.Version, verification.SigningKeyID), result)
+}
+
+func (c *VerifyCommand) verify(verifier *releases.Verifier, path string, isArchive bool, logger *log.Logger) (*releases.Verification, error) {
+	verifier.SetLogger(logger)
+
+	ctx := context.Background()
+	if isArchive {
+		return verifier.VerifyArchive(ctx, path)
+	}
+	return verifier.VerifyBinary(ctx, path)
+}
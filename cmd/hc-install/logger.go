@@ -0,0 +1,25 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+)
+
+// newLogger returns a logger writing into the file at logFilePath
+// or a logger which discards all output if the path is empty
+func newLogger(logFilePath string) (*log.Logger, error) {
+	if logFilePath == "" {
+		return log.New(io.Discard, "", 0), nil
+	}
+
+	f, err := os.OpenFile(logFilePath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("unable to log into %q: %s", logFilePath, err)
+	}
+	return log.New(f, "[DEBUG] ", log.LstdFlags|log.Lshortfile|log.Lmicroseconds), nil
+}
@@ -31,12 +31,45 @@ func main() {
 		},
 	}
 
+	args, jsonOutput := extractGlobalFlags(os.Args[1:])
+
 	c := cli.NewCLI("hc-install", version.Version().String())
-	c.Args = os.Args[1:]
+	c.Args = args
 	c.Commands = map[string]cli.CommandFactory{
+		"build": func() (cli.Command, error) {
+			return &BuildCommand{
+				Ui:   ui,
+				JSON: jsonOutput,
+			}, nil
+		},
+		"gc": func() (cli.Command, error) {
+			return &GCCommand{
+				Ui:   ui,
+				JSON: jsonOutput,
+			}, nil
+		},
 		"install": func() (cli.Command, error) {
 			return &InstallCommand{
-				Ui: ui,
+				Ui:   ui,
+				JSON: jsonOutput,
+			}, nil
+		},
+		"outdated": func() (cli.Command, error) {
+			return &OutdatedCommand{
+				Ui:   ui,
+				JSON: jsonOutput,
+			}, nil
+		},
+		"uninstall": func() (cli.Command, error) {
+			return &UninstallCommand{
+				Ui:   ui,
+				JSON: jsonOutput,
+			}, nil
+		},
+		"verify": func() (cli.Command, error) {
+			return &VerifyCommand{
+				Ui:   ui,
+				JSON: jsonOutput,
 			}, nil
 		},
 	}
@@ -48,3 +81,18 @@ func main() {
 
 	os.Exit(exitStatus)
 }
+
+// extractGlobalFlags removes any global flags which precede
+// the command name and returns the remaining arguments
+func extractGlobalFlags(args []string) ([]string, bool) {
+	jsonOutput := false
+	for i, arg := range args {
+		switch arg {
+		case "-json", "--json":
+			jsonOutput = true
+		default:
+			return args[i:], jsonOutput
+		}
+	}
+	return []string{}, jsonOutput
+}
@@ -0,0 +1,173 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/cli"
+	hcerrors "github.com/hashicorp/hc-install/errors"
+	"github.com/hashicorp/hc-install/policy"
+)
+
+// errorCode represents a stable identifier of a failure
+// which wrappers can rely on in machine-readable output
+type errorCode string
+
+const (
+	errCodeUsage          errorCode = "usage_error"
+	errCodeUnknownProduct errorCode = "unknown_product"
+	errCodeInvalidVersion errorCode = "invalid_version"
+	errCodeInvalidPath    errorCode = "invalid_path"
+	errCodeLogSetup       errorCode = "log_setup_failed"
+	errCodeTimeout        errorCode = "timeout"
+	errCodeInstallFailed  errorCode = "install_failed"
+	errCodeBuildFailed    errorCode = "build_failed"
+	errCodePolicyDenied   errorCode = "policy_denied"
+	errCodeCheckFailed    errorCode = "check_failed"
+	errCodeGCFailed       errorCode = "gc_failed"
+	errCodeNotInstalled   errorCode = "not_installed"
+	errCodeRemoveFailed   errorCode = "remove_failed"
+
+	errCodeVerifyFailed      errorCode = "verify_failed"
+	errCodeNoMatchingRelease errorCode = "no_matching_release"
+	errCodeVersionMismatch   errorCode = "version_mismatch"
+)
+
+type commandError struct {
+	Code    errorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+// commandResult represents the machine-readable result
+// of any command, fields irrelevant to the command are omitted
+type commandResult struct {
+	Product      string        `json:"product,omitempty"`
+	Version      string        `json:"version,omitempty"`
+	Ref          string        `json:"ref,omitempty"`
+	Revision     string        `json:"revision,omitempty"`
+	Path         string        `json:"path,omitempty"`
+	SHA256       string        `json:"sha256,omitempty"`
+	SigningKeyID string        `json:"signing_key_id,omitempty"`
+	LicensePaths []string      `json:"license_paths,omitempty"`
+	MatchedFile  string        `json:"matched_file,omitempty"`
+	ArchiveURL   string        `json:"archive_url,omitempty"`
+	ReceiptPath  string        `json:"receipt_path,omitempty"`
+	DryRun       bool          `json:"dry_run,omitempty"`
+	DurationMs   int64         `json:"duration_ms"`
+	Error        *commandError `json:"error,omitempty"`
+
+	// Installations represents installed binaries reported by outdated
+	Installations []*installationResult `json:"installations,omitempty"`
+
+	// RemovedPaths, RestoredPaths and KeptPaths represent paths
+	// removed, restored from backups and kept by uninstall
+	RemovedPaths  []string `json:"removed_paths,omitempty"`
+	RestoredPaths []string `json:"restored_paths,omitempty"`
+	KeptPaths     []string `json:"kept_paths,omitempty"`
+
+	// TempDirs represents temporary directories reported by gc
+	TempDirs []*tempDirResult `json:"temp_dirs,omitempty"`
+}
+
+// installationResult represents an installed binary
+// and newer versions available for it
+type installationResult struct {
+	Product       string `json:"product"`
+	Path          string `json:"path"`
+	Version       string `json:"version"`
+	LatestVersion string `json:"latest_version,omitempty"`
+	UpdateVersion string `json:"update_version,omitempty"`
+	Outdated      bool   `json:"outdated"`
+}
+
+// tempDirResult represents a temporary directory created by hc-install
+type tempDirResult struct {
+	Path      string `json:"path"`
+	CreatedAt string `json:"created_at"`
+	PID       int    `json:"pid"`
+	Hostname  string `json:"hostname"`
+	SizeBytes int64  `json:"size_bytes"`
+	Locked    bool   `json:"locked"`
+	Stale     bool   `json:"stale"`
+	Removed   bool   `json:"removed"`
+	Error     string `json:"error,omitempty"`
+}
+
+// output takes care of reporting command progress and results
+// either as human-readable messages or as a single JSON object
+type output struct {
+	ui        cli.Ui
+	json      bool
+	startTime time.Time
+}
+
+func newOutput(ui cli.Ui, jsonOutput bool) *output {
+	return &output{
+		ui:        ui,
+		json:      jsonOutput,
+		startTime: time.Now(),
+	}
+}
+
+// Info reports progress, which is only relevant to humans
+func (o *output) Info(msg string) {
+	if o.json {
+		return
+	}
+	o.ui.Info(msg)
+}
+
+// Success reports the result of a successful command
+// and returns the exit code
+func (o *output) Success(msg string, result *commandResult) int {
+	if !o.json {
+		o.ui.Info(msg)
+		return 0
+	}
+	result.DurationMs = time.Since(o.startTime).Milliseconds()
+	o.writeJSON(result)
+	return 0
+}
+
+// Fail reports an error and returns the exit code
+func (o *output) Fail(code errorCode, err error, result *commandResult) int {
+	if !o.json {
+		o.ui.Error(err.Error())
+		return 1
+	}
+	if result == nil {
+		result = &commandResult{}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		code = errCodeTimeout
+	}
+	if policy.IsDenied(err) {
+		code = errCodePolicyDenied
+	}
+	var mismatchErr *hcerrors.VersionMismatchError
+	if errors.As(err, &mismatchErr) {
+		code = errCodeVersionMismatch
+	}
+	result.DurationMs = time.Since(o.startTime).Milliseconds()
+	result.Error = &commandError{
+		Code:    code,
+		Message: err.Error(),
+	}
+	o.writeJSON(result)
+	return 1
+}
+
+func (o *output) writeJSON(v interface{}) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		o.ui.Error(fmt.Sprintf("failed to encode output: %s", err))
+		return
+	}
+	o.ui.Output(string(b))
+}
@@ -0,0 +1,49 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+
+	"github.com/hashicorp/hc-install/product"
+)
+
+// knownProducts represents products which come with
+// version getter and build instructions
+var knownProducts = map[string]product.Product{
+	product.Consul.Name:    product.Consul,
+	product.Nomad.Name:     product.Nomad,
+	product.Packer.Name:    product.Packer,
+	product.Terraform.Name: product.Terraform,
+	product.Vault.Name:     product.Vault,
+}
+
+func knownProduct(name string) (product.Product, error) {
+	p, ok := knownProducts[name]
+	if !ok {
+		names := make([]string, 0, len(knownProducts))
+		for n := range knownProducts {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return product.Product{}, fmt.Errorf("unknown product %q (expected one of %q)", name, names)
+	}
+	return p, nil
+}
+
+// namedProduct returns a product of the given name
+// with binary name following the common convention
+func namedProduct(name string) product.Product {
+	return product.Product{
+		Name: name,
+		BinaryName: func() string {
+			if runtime.GOOS == "windows" {
+				return fmt.Sprintf("%s.exe", name)
+			}
+			return name
+		},
+	}
+}
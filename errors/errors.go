@@ -3,6 +3,12 @@
 
 package errors
 
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+)
+
 type skippableErr struct {
 	Err error
 }
@@ -19,3 +25,17 @@ func IsErrorSkippable(err error) bool {
 	_, ok := err.(skippableErr)
 	return ok
 }
+
+// VersionMismatchError is returned when an installed binary reports
+// a different version (or enterprise metadata) than the one requested,
+// e.g. when a mirror serves a mislabeled archive with valid checksums
+type VersionMismatchError struct {
+	ExecPath string
+	Expected *version.Version
+	Actual   *version.Version
+}
+
+func (e *VersionMismatchError) Error() string {
+	return fmt.Sprintf("%s reports version %s, expected %s",
+		e.ExecPath, e.Actual, e.Expected)
+}
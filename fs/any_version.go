@@ -10,9 +10,9 @@ import (
 	"path/filepath"
 
 	"github.com/hashicorp/hc-install/errors"
-	"github.com/hashicorp/hc-install/internal/src"
 	"github.com/hashicorp/hc-install/internal/validators"
 	"github.com/hashicorp/hc-install/product"
+	"github.com/hashicorp/hc-install/src"
 )
 
 // AnyVersion finds an executable binary of any version
@@ -34,6 +34,12 @@ type AnyVersion struct {
 	// the default system $PATH, conflicts with ExactBinPath
 	ExtraPaths []string
 
+	// DiscoverManagedInstalls indicates that versioned installations
+	// managed by tfenv, asdf, mise, Homebrew (Cellar) and previous
+	// installations into temporary directories by hc-install
+	// should be looked up too, conflicts with ExactBinPath
+	DiscoverManagedInstalls bool
+
 	// ExactBinPath represents exact path to the binary,
 	// conflicts with Product and ExtraPaths
 	ExactBinPath string
@@ -49,14 +55,20 @@ func (av *AnyVersion) Validate() error {
 	if av.ExactBinPath == "" && av.Product == nil {
 		return fmt.Errorf("must use either ExactBinPath or Product + ExtraPaths")
 	}
-	if av.ExactBinPath != "" && (av.Product != nil || len(av.ExtraPaths) > 0) {
+	if av.ExactBinPath != "" && (av.Product != nil || len(av.ExtraPaths) > 0 || av.DiscoverManagedInstalls) {
 		return fmt.Errorf("use either ExactBinPath or Product + ExtraPaths, not both")
 	}
 	if av.ExactBinPath != "" && !filepath.IsAbs(av.ExactBinPath) {
 		return fmt.Errorf("expected ExactBinPath (%q) to be an absolute path", av.ExactBinPath)
 	}
-	if av.Product != nil && !validators.IsBinaryNameValid(av.Product.BinaryName()) {
-		return fmt.Errorf("invalid binary name: %q", av.Product.BinaryName())
+	if av.Product != nil {
+		// name may depend on the version, which isn't known yet
+		if target := product.CurrentTarget(nil); av.Product.BinaryNameKnown(target) {
+			binaryName := av.Product.BinaryNameFor(target)
+			if !validators.IsBinaryNameValid(binaryName) {
+				return fmt.Errorf("invalid binary name: %q", binaryName)
+			}
+		}
 	}
 	return nil
 }
@@ -65,6 +77,10 @@ func (av *AnyVersion) SetLogger(logger *log.Logger) {
 	av.logger = logger
 }
 
+func (av *AnyVersion) searchDirs() []string {
+	return searchDirs(av.Product.Name, av.ExtraPaths, av.DiscoverManagedInstalls)
+}
+
 func (av *AnyVersion) log() *log.Logger {
 	if av.logger == nil {
 		return discardLogger
@@ -82,7 +98,12 @@ func (av *AnyVersion) Find(ctx context.Context) (string, error) {
 		return av.ExactBinPath, nil
 	}
 
-	execPath, err := findFile(lookupDirs(av.ExtraPaths), av.Product.BinaryName(), checkExecutable)
+	binaryName, err := binaryNameFor(*av.Product, nil)
+	if err != nil {
+		return "", errors.SkippableErr(err)
+	}
+
+	execPath, err := findFile(av.searchDirs(), binaryName, checkExecutable)
 	if err != nil {
 		return "", errors.SkippableErr(err)
 	}
@@ -96,3 +117,13 @@ func (av *AnyVersion) Find(ctx context.Context) (string, error) {
 	}
 	return execPath, nil
 }
+
+// Resolve determines which binary would be found,
+// which is the same as Find, since finding makes no changes
+func (av *AnyVersion) Resolve(ctx context.Context) (*src.Resolution, error) {
+	execPath, err := av.Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &src.Resolution{ExecPath: execPath}, nil
+}
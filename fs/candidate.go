@@ -0,0 +1,104 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package fs
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/go-version"
+)
+
+// SelectionStrategy determines which of the binaries
+// matching version constraints is found
+type SelectionStrategy int
+
+const (
+	// SelectFirst selects the first matching binary
+	// in order of lookup directories (default)
+	SelectFirst SelectionStrategy = iota
+
+	// SelectHighest selects the binary with the highest matching version
+	SelectHighest
+
+	// SelectLowest selects the binary with the lowest matching version
+	SelectLowest
+)
+
+func (s SelectionStrategy) String() string {
+	switch s {
+	case SelectFirst:
+		return "first"
+	case SelectHighest:
+		return "highest"
+	case SelectLowest:
+		return "lowest"
+	}
+	return fmt.Sprintf("SelectionStrategy(%d)", int(s))
+}
+
+// Candidate represents a binary found within lookup directories
+type Candidate struct {
+	ExecPath string
+	Version  *version.Version
+}
+
+// rankCandidates sorts candidates according to the strategy,
+// keeping the order of lookup directories for equal versions
+func rankCandidates(candidates []*Candidate, s SelectionStrategy) error {
+	switch s {
+	case SelectFirst:
+		return nil
+	case SelectHighest:
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].Version.GreaterThan(candidates[j].Version)
+		})
+	case SelectLowest:
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].Version.LessThan(candidates[j].Version)
+		})
+	default:
+		return fmt.Errorf("unknown selection strategy: %s", s)
+	}
+	return nil
+}
+
+// findCandidates returns all binaries within dirs
+// for which check returns the version
+func findCandidates(dirs []string, file string, check func(path string) (*version.Version, error)) ([]*Candidate, error) {
+	versions := make(map[string]*version.Version, 0)
+	paths := findFiles(dirs, file, func(path string) error {
+		v, err := check(path)
+		if err != nil {
+			return err
+		}
+		versions[path] = v
+		return nil
+	})
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%s: %w", file, exec.ErrNotFound)
+	}
+
+	candidates := make([]*Candidate, 0, len(paths))
+	for _, path := range paths {
+		execPath, err := absPath(path)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, &Candidate{
+			ExecPath: execPath,
+			Version:  versions[path],
+		})
+	}
+	return candidates, nil
+}
+
+func absPath(path string) (string, error) {
+	if filepath.IsAbs(path) {
+		return path, nil
+	}
+	return filepath.Abs(path)
+}
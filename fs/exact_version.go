@@ -7,14 +7,15 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"path/filepath"
 	"time"
 
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hc-install/errors"
-	"github.com/hashicorp/hc-install/internal/src"
 	"github.com/hashicorp/hc-install/internal/validators"
+	"github.com/hashicorp/hc-install/internal/versioncheck"
+	"github.com/hashicorp/hc-install/policy"
 	"github.com/hashicorp/hc-install/product"
+	"github.com/hashicorp/hc-install/src"
 )
 
 // ExactVersion finds the first executable binary of the product name
@@ -26,7 +27,33 @@ type ExactVersion struct {
 	ExtraPaths []string
 	Timeout    time.Duration
 
-	logger *log.Logger
+	// DiscoverManagedInstalls indicates that versioned installations
+	// managed by tfenv, asdf, mise, Homebrew (Cellar) and previous
+	// installations into temporary directories by hc-install
+	// should be looked up too (after $PATH and ExtraPaths)
+	DiscoverManagedInstalls bool
+
+	// PreferBuildInfo indicates that the version of found binaries should
+	// be read without executing them (via Product.ReadVersion, e.g. from
+	// Go build info), falling back to executing them via Product.GetVersion
+	PreferBuildInfo bool
+
+	// TrustReceipts indicates that the version recorded in the install
+	// receipt of a found binary (see package receipt) is used instead
+	// of detecting it, provided the binary matches the receipt
+	TrustReceipts bool
+
+	// VersionCache represents an optional on-disk cache of detected
+	// versions, so that unchanged binaries don't have to be executed again
+	VersionCache *VersionCache
+
+	// Policy represents an optional policy which has to allow
+	// the version of a binary for it to be found
+	Policy policy.Policy
+
+	logger          *log.Logger
+	artifact        *src.Artifact
+	installerPolicy policy.Policy
 }
 
 func (*ExactVersion) IsSourceImpl() src.InstallSrcSigil {
@@ -37,6 +64,24 @@ func (ev *ExactVersion) SetLogger(logger *log.Logger) {
 	ev.logger = logger
 }
 
+// SetPolicy sets a policy which is consulted in addition to Policy
+func (ev *ExactVersion) SetPolicy(p policy.Policy) {
+	ev.installerPolicy = p
+}
+
+func (ev *ExactVersion) searchDirs() []string {
+	return searchDirs(ev.Product.Name, ev.ExtraPaths, ev.DiscoverManagedInstalls)
+}
+
+func (ev *ExactVersion) versionDetector() *versionDetector {
+	return &versionDetector{
+		product:           ev.Product,
+		preferReadVersion: ev.PreferBuildInfo,
+		trustReceipts:     ev.TrustReceipts,
+		cache:             ev.VersionCache,
+	}
+}
+
 func (ev *ExactVersion) log() *log.Logger {
 	if ev.logger == nil {
 		return discardLogger
@@ -45,19 +90,24 @@ func (ev *ExactVersion) log() *log.Logger {
 }
 
 func (ev *ExactVersion) Validate() error {
-	if !validators.IsBinaryNameValid(ev.Product.BinaryName()) {
-		return fmt.Errorf("invalid binary name: %q", ev.Product.BinaryName())
+	binaryName := ev.Product.BinaryNameFor(product.CurrentTarget(ev.Version))
+	if !validators.IsBinaryNameValid(binaryName) {
+		return fmt.Errorf("invalid binary name: %q", binaryName)
 	}
 	if ev.Version == nil {
 		return fmt.Errorf("undeclared version")
 	}
-	if ev.Product.GetVersion == nil {
-		return fmt.Errorf("undeclared version getter")
+	if err := versioncheck.Validate(ev.Product, ev.PreferBuildInfo); err != nil {
+		return err
 	}
 	return nil
 }
 
 func (ev *ExactVersion) Find(ctx context.Context) (string, error) {
+	return ev.find(ctx, ev.versionDetector())
+}
+
+func (ev *ExactVersion) find(ctx context.Context, vd *versionDetector) (string, error) {
 	timeout := defaultTimeout
 	if ev.Timeout > 0 {
 		timeout = ev.Timeout
@@ -65,34 +115,95 @@ func (ev *ExactVersion) Find(ctx context.Context) (string, error) {
 	ctx, cancelFunc := context.WithTimeout(ctx, timeout)
 	defer cancelFunc()
 
-	execPath, err := findFile(lookupDirs(ev.ExtraPaths), ev.Product.BinaryName(), func(file string) error {
-		err := checkExecutable(file)
+	var foundVersion *version.Version
+	execPath, err := findFile(ev.searchDirs(), ev.Product.BinaryNameFor(product.CurrentTarget(ev.Version)), func(file string) error {
+		v, err := ev.check(ctx, vd, file)
 		if err != nil {
 			return err
 		}
-
-		v, err := ev.Product.GetVersion(ctx, file)
-		if err != nil {
-			return err
-		}
-
-		if !ev.Version.Equal(v) {
-			return fmt.Errorf("version (%s) doesn't match %s", v, ev.Version)
-		}
-
+		foundVersion = v
 		return nil
 	})
 	if err != nil {
 		return "", errors.SkippableErr(err)
 	}
 
-	if !filepath.IsAbs(execPath) {
-		var err error
-		execPath, err = filepath.Abs(execPath)
-		if err != nil {
-			return "", errors.SkippableErr(err)
-		}
+	execPath, err = absPath(execPath)
+	if err != nil {
+		return "", errors.SkippableErr(err)
 	}
 
+	ev.artifact = &src.Artifact{Version: foundVersion}
+
 	return execPath, nil
 }
+
+// FindAll finds all executable binaries matching the Version
+// in order of lookup directories
+func (ev *ExactVersion) FindAll(ctx context.Context) ([]*Candidate, error) {
+	timeout := defaultTimeout
+	if ev.Timeout > 0 {
+		timeout = ev.Timeout
+	}
+	ctx, cancelFunc := context.WithTimeout(ctx, timeout)
+	defer cancelFunc()
+
+	candidates, err := findCandidates(ev.searchDirs(), ev.Product.BinaryNameFor(product.CurrentTarget(ev.Version)), func(file string) (*version.Version, error) {
+		return ev.check(ctx, ev.versionDetector(), file)
+	})
+	if err != nil {
+		return nil, errors.SkippableErr(err)
+	}
+	return candidates, nil
+}
+
+// check returns the version of the binary if it is executable,
+// matches the Version and is allowed by policies
+func (ev *ExactVersion) check(ctx context.Context, vd *versionDetector, file string) (*version.Version, error) {
+	err := checkExecutable(file)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := vd.version(ctx, ev.log(), file)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ev.Version.Equal(v) {
+		return nil, fmt.Errorf("version (%s) doesn't match %s", v, ev.Version)
+	}
+
+	err = policy.Policies{ev.Policy, ev.installerPolicy}.Check(ctx, &policy.Candidate{
+		Product: ev.Product.Name,
+		Version: v,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// Artifact returns details of the binary found by the last
+// successful Find, or nil if nothing was found yet
+func (ev *ExactVersion) Artifact() *src.Artifact {
+	return ev.artifact
+}
+
+// Resolve determines which binary would be found,
+// which is the same as Find, except that detected
+// versions are not stored in VersionCache
+func (ev *ExactVersion) Resolve(ctx context.Context) (*src.Resolution, error) {
+	vd := ev.versionDetector()
+	vd.readOnlyCache = true
+
+	execPath, err := ev.find(ctx, vd)
+	if err != nil {
+		return nil, err
+	}
+	return &src.Resolution{
+		Version:  ev.artifact.Version,
+		ExecPath: execPath,
+	}, nil
+}
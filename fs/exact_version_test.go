@@ -26,6 +26,17 @@ func TestExactVersionValidate(t *testing.T) {
 			},
 			expectedErr: fmt.Errorf("invalid binary name: \"invalid!\""),
 		},
+		"Product-incorrect-target-binary-name": {
+			ev: ExactVersion{
+				Product: product.Product{
+					TargetBinaryName: func(t product.Target) string {
+						return fmt.Sprintf("invalid_v%s!", t.Version)
+					},
+				},
+				Version: version.Must(version.NewVersion("1.0.0")),
+			},
+			expectedErr: fmt.Errorf("invalid binary name: \"invalid_v1.0.0!\""),
+		},
 		"Product-missing-get-version": {
 			ev: ExactVersion{
 				Product: product.Product{
@@ -35,6 +46,16 @@ func TestExactVersionValidate(t *testing.T) {
 			},
 			expectedErr: fmt.Errorf("undeclared version getter"),
 		},
+		"Product-missing-get-version-read-preferred": {
+			ev: ExactVersion{
+				Product: product.Product{
+					BinaryName:  product.Terraform.BinaryName,
+					ReadVersion: product.Terraform.ReadVersion,
+				},
+				Version:         version.Must(version.NewVersion("1.0.0")),
+				PreferBuildInfo: true,
+			},
+		},
 		"Product-and-Version": {
 			ev: ExactVersion{
 				Product: product.Terraform,
@@ -4,9 +4,18 @@
 package fs
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"io"
+	"io/fs"
 	"log"
 	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/internal/versioncheck"
+	"github.com/hashicorp/hc-install/product"
+	"github.com/hashicorp/hc-install/receipt"
 )
 
 var (
@@ -15,3 +24,88 @@ var (
 )
 
 type fileCheckFunc func(path string) error
+
+// versionDetector obtains versions of found binaries
+type versionDetector struct {
+	product product.Product
+
+	// preferReadVersion indicates that Product.ReadVersion
+	// is preferred over Product.GetVersion
+	preferReadVersion bool
+
+	// trustReceipts indicates that the version recorded
+	// in a verified install receipt is used if present
+	trustReceipts bool
+
+	cache *VersionCache
+
+	// readOnlyCache indicates that the cache is consulted
+	// but detected versions are not stored in it,
+	// such that resolution makes no filesystem changes
+	readOnlyCache bool
+}
+
+// version obtains the version of the binary from the cache (if any),
+// or via detect
+func (vd *versionDetector) version(ctx context.Context, logger *log.Logger, path string) (*version.Version, error) {
+	if vd.cache == nil {
+		return vd.detect(ctx, logger, path)
+	}
+	if vd.readOnlyCache {
+		v, _ := vd.cache.lookup(logger, vd.product.Name, path)
+		if v != nil {
+			return v, nil
+		}
+		return vd.detect(ctx, logger, path)
+	}
+	return vd.cache.version(logger, vd.product.Name, path, func() (*version.Version, error) {
+		return vd.detect(ctx, logger, path)
+	})
+}
+
+// detect obtains the version of the binary from its receipt (if trusted),
+// or via versioncheck.Detect
+func (vd *versionDetector) detect(ctx context.Context, logger *log.Logger, path string) (*version.Version, error) {
+	p := vd.product
+
+	if vd.trustReceipts {
+		v, err := receiptVersion(p.Name, path)
+		if err == nil {
+			logger.Printf("using version of %s from its receipt: %s", path, v)
+			return v, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			logger.Printf("ignoring receipt of %s: %s", path, err)
+		}
+	}
+
+	return versioncheck.Detect(ctx, logger, p, vd.preferReadVersion, path)
+}
+
+// receiptVersion returns the version recorded in the receipt
+// of the binary, if the receipt is valid for the product and binary
+func receiptVersion(productName, path string) (*version.Version, error) {
+	r, err := receipt.Read(path)
+	if err != nil {
+		return nil, err
+	}
+	if r.Product != productName {
+		return nil, fmt.Errorf("unexpected product %q", r.Product)
+	}
+	err = r.Verify(path)
+	if err != nil {
+		return nil, err
+	}
+	return version.NewVersion(r.Version)
+}
+
+// binaryNameFor returns name of the binary of the given version,
+// which can't be determined if it depends on the version and
+// the version isn't known upfront (nil)
+func binaryNameFor(p product.Product, v *version.Version) (string, error) {
+	t := product.CurrentTarget(v)
+	if !p.BinaryNameKnown(t) {
+		return "", fmt.Errorf("name of %s binary depends on its version, which isn't known upfront", p.Name)
+	}
+	return p.BinaryNameFor(t), nil
+}
@@ -5,12 +5,15 @@ package fs
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hc-install/internal/testutil"
 	"github.com/hashicorp/hc-install/product"
+	"github.com/hashicorp/hc-install/receipt"
 	"github.com/hashicorp/hc-install/releases"
 	"github.com/hashicorp/hc-install/src"
 )
@@ -18,12 +21,15 @@ import (
 var (
 	_ src.Findable       = &AnyVersion{}
 	_ src.LoggerSettable = &AnyVersion{}
+	_ src.Resolvable     = &AnyVersion{}
 
 	_ src.Findable       = &ExactVersion{}
 	_ src.LoggerSettable = &ExactVersion{}
+	_ src.Resolvable     = &ExactVersion{}
 
 	_ src.Findable       = &Version{}
 	_ src.LoggerSettable = &Version{}
+	_ src.Resolvable     = &Version{}
 )
 
 func TestExactVersion(t *testing.T) {
@@ -102,3 +108,113 @@ func TestVersion(t *testing.T) {
 		t.Fatal("expecting error")
 	}
 }
+
+func TestVersionDetector(t *testing.T) {
+	t.Parallel()
+
+	readVersion := version.Must(version.NewVersion("1.2.0"))
+	execVersion := version.Must(version.NewVersion("1.1.0"))
+	receiptVersion := version.Must(version.NewVersion("1.3.0"))
+
+	dir := t.TempDir()
+	for _, name := range []string{"readable", "unreadable", "receipt", "tampered", "other-product"} {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o700)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, productName := range map[string]string{
+		"receipt":       "fake",
+		"tampered":      "fake",
+		"other-product": "other",
+	} {
+		_, err := receipt.Write(filepath.Join(dir, name), &receipt.Receipt{
+			Product: productName,
+			Version: receiptVersion.String(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := os.WriteFile(filepath.Join(dir, "tampered"), []byte("modified"), 0o700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := product.Product{
+		Name: "fake",
+		ReadVersion: func(_ context.Context, path string) (*version.Version, error) {
+			if filepath.Base(path) == "unreadable" {
+				return nil, fmt.Errorf("no build info")
+			}
+			return readVersion, nil
+		},
+		GetVersion: func(_ context.Context, _ string) (*version.Version, error) {
+			return execVersion, nil
+		},
+	}
+
+	testCases := map[string]struct {
+		vd              *versionDetector
+		path            string
+		expectedVersion *version.Version
+	}{
+		"executed-by-default": {
+			vd:              &versionDetector{product: p},
+			path:            "readable",
+			expectedVersion: execVersion,
+		},
+		"read-if-preferred": {
+			vd:              &versionDetector{product: p, preferReadVersion: true},
+			path:            "readable",
+			expectedVersion: readVersion,
+		},
+		"executed-if-unreadable": {
+			vd:              &versionDetector{product: p, preferReadVersion: true},
+			path:            "unreadable",
+			expectedVersion: execVersion,
+		},
+		"receipt-untrusted": {
+			vd:              &versionDetector{product: p},
+			path:            "receipt",
+			expectedVersion: execVersion,
+		},
+		"receipt-trusted": {
+			vd:              &versionDetector{product: p, trustReceipts: true},
+			path:            "receipt",
+			expectedVersion: receiptVersion,
+		},
+		"receipt-missing": {
+			vd:              &versionDetector{product: p, trustReceipts: true},
+			path:            "readable",
+			expectedVersion: execVersion,
+		},
+		"receipt-tampered-binary": {
+			vd:              &versionDetector{product: p, trustReceipts: true},
+			path:            "tampered",
+			expectedVersion: execVersion,
+		},
+		"receipt-other-product": {
+			vd:              &versionDetector{product: p, trustReceipts: true},
+			path:            "other-product",
+			expectedVersion: execVersion,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			v, err := testCase.vd.version(context.Background(), testutil.TestLogger(),
+				filepath.Join(dir, testCase.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !v.Equal(testCase.expectedVersion) {
+				t.Fatalf("expected version %s, got %s", testCase.expectedVersion, v)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"syscall"
 )
 
 func lookupDirs(extraDirs []string) []string {
@@ -34,6 +35,27 @@ func findFile(dirs []string, file string, f fileCheckFunc) (string, error) {
 	return "", fmt.Errorf("%s: %w", file, exec.ErrNotFound)
 }
 
+// findFiles returns all files (in order of dirs) which pass the check
+func findFiles(dirs []string, file string, f fileCheckFunc) []string {
+	paths := make([]string, 0)
+	seen := make(map[string]bool, 0)
+	for _, dir := range dirs {
+		if dir == "" {
+			// Unix shell semantics: path element "" means "."
+			dir = "."
+		}
+		path := filepath.Join(dir, file)
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		if err := f(path); err == nil {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
 func checkExecutable(file string) error {
 	d, err := os.Stat(file)
 	if err != nil {
@@ -44,3 +66,11 @@ func checkExecutable(file string) error {
 	}
 	return os.ErrPermission
 }
+
+// fileID returns the inode of the file
+func fileID(fi os.FileInfo) uint64 {
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok {
+		return uint64(st.Ino)
+	}
+	return 0
+}
@@ -10,8 +10,10 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hc-install/errors"
 	"github.com/hashicorp/hc-install/internal/testutil"
 	"github.com/hashicorp/hc-install/product"
@@ -102,3 +104,144 @@ func TestAnyVersion_exactBinPath_notExecutable(t *testing.T) {
 		t.Fatalf("expected a skippable error, got: %#v", err)
 	}
 }
+
+func TestVersion_strategy(t *testing.T) {
+	t.Setenv("PATH", "")
+	dirs := testutil.CreateFakeBinaries(t, "fake", "1.5.0", "1.2.0", "1.7.0", "0.9.0", "1.7.0")
+
+	testCases := map[string]struct {
+		strategy         SelectionStrategy
+		expectedPath     string
+		expectedVersions []string
+	}{
+		"first": {
+			strategy:         SelectFirst,
+			expectedPath:     filepath.Join(dirs[0], "fake"),
+			expectedVersions: []string{"1.5.0", "1.2.0", "1.7.0", "1.7.0"},
+		},
+		"highest": {
+			strategy:         SelectHighest,
+			expectedPath:     filepath.Join(dirs[2], "fake"),
+			expectedVersions: []string{"1.7.0", "1.7.0", "1.5.0", "1.2.0"},
+		},
+		"lowest": {
+			strategy:         SelectLowest,
+			expectedPath:     filepath.Join(dirs[1], "fake"),
+			expectedVersions: []string{"1.2.0", "1.5.0", "1.7.0", "1.7.0"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			v := &Version{
+				Product:     testutil.FakeProduct("fake"),
+				Constraints: version.MustConstraints(version.NewConstraint(">= 1.0")),
+				ExtraPaths:  dirs,
+				Strategy:    testCase.strategy,
+			}
+			v.SetLogger(testutil.TestLogger())
+
+			execPath, err := v.Find(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if execPath != testCase.expectedPath {
+				t.Fatalf("expected %q, found %q", testCase.expectedPath, execPath)
+			}
+
+			candidates, err := v.FindAll(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			versions := make([]string, 0, len(candidates))
+			for _, c := range candidates {
+				versions = append(versions, c.Version.String())
+			}
+			if strings.Join(versions, " ") != strings.Join(testCase.expectedVersions, " ") {
+				t.Fatalf("expected versions %q, found %q", testCase.expectedVersions, versions)
+			}
+		})
+	}
+}
+
+func TestExactVersion_FindAll(t *testing.T) {
+	t.Setenv("PATH", "")
+	dirs := testutil.CreateFakeBinaries(t, "fake", "1.5.0", "1.2.0", "1.5.0")
+
+	ev := &ExactVersion{
+		Product:    testutil.FakeProduct("fake"),
+		Version:    version.Must(version.NewVersion("1.5.0")),
+		ExtraPaths: append(dirs, dirs[0]),
+	}
+	ev.SetLogger(testutil.TestLogger())
+
+	candidates, err := ev.FindAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candidates) != 2 {
+		t.Fatalf("expected 2 candidates, found %d", len(candidates))
+	}
+	if candidates[0].ExecPath != filepath.Join(dirs[0], "fake") ||
+		candidates[1].ExecPath != filepath.Join(dirs[2], "fake") {
+		t.Fatalf("unexpected candidates: %q, %q", candidates[0].ExecPath, candidates[1].ExecPath)
+	}
+
+	ev.Version = version.Must(version.NewVersion("2.0.0"))
+	_, err = ev.FindAll(context.Background())
+	if !errors.IsErrorSkippable(err) {
+		t.Fatalf("expected a skippable error, got: %#v", err)
+	}
+}
+
+func TestVersion_discoverManagedInstalls(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("PATH", "")
+	t.Setenv("HOME", homeDir)
+	t.Setenv("TMPDIR", t.TempDir())
+	t.Setenv("ASDF_DATA_DIR", "")
+	t.Setenv("MISE_DATA_DIR", "")
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("HOMEBREW_CELLAR", filepath.Join(homeDir, "Cellar"))
+
+	binaries := map[string]string{
+		filepath.Join(homeDir, ".asdf", "installs", "fake", "1.2.0", "bin"):            "1.2.0",
+		filepath.Join(homeDir, ".local", "share", "mise", "installs", "fake", "1.6.0"): "1.6.0",
+		filepath.Join(homeDir, "Cellar", "fake", "1.4.0", "bin"):                       "1.4.0",
+		filepath.Join(os.TempDir(), "fake_12345"):                                      "1.3.0",
+	}
+	for dir, v := range binaries {
+		err := os.MkdirAll(dir, 0o755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(filepath.Join(dir, "fake"), []byte(v), 0o700)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	v := &Version{
+		Product:     testutil.FakeProduct("fake"),
+		Constraints: version.MustConstraints(version.NewConstraint(">= 1.0")),
+		Strategy:    SelectHighest,
+	}
+	v.SetLogger(testutil.TestLogger())
+
+	_, err := v.Find(context.Background())
+	if !errors.IsErrorSkippable(err) {
+		t.Fatalf("expected binary not to be found without discovery, got: %v", err)
+	}
+
+	v.DiscoverManagedInstalls = true
+	candidates, err := v.FindAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candidates) != len(binaries) {
+		t.Fatalf("expected %d candidates, found %d", len(binaries), len(candidates))
+	}
+	if candidates[0].Version.String() != "1.6.0" {
+		t.Fatalf("expected highest version 1.6.0, found %s", candidates[0].Version)
+	}
+}
@@ -31,6 +31,23 @@ func findFile(dirs []string, file string, f fileCheckFunc) (string, error) {
 	return "", fmt.Errorf("%s: %w", file, exec.ErrNotFound)
 }
 
+// findFiles returns all files (in order of dirs) which pass the check
+func findFiles(dirs []string, file string, f fileCheckFunc) []string {
+	paths := make([]string, 0)
+	seen := make(map[string]bool, 0)
+	for _, dir := range dirs {
+		path := filepath.Join(dir, file)
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		if err := f(path); err == nil {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
 func checkExecutable(file string) error {
 	var exts []string
 	x := os.Getenv(`PATHEXT`)
@@ -82,3 +99,9 @@ func hasExt(file string) bool {
 	}
 	return strings.LastIndexAny(file, `:\/`) < i
 }
+
+// fileID is not available on Windows via os.FileInfo,
+// so files are identified by size and modification time only
+func fileID(fi os.FileInfo) uint64 {
+	return 0
+}
@@ -0,0 +1,107 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package fs
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// managedDirs returns existing directories of versioned installations
+// of the product managed by well-known version managers (tfenv, asdf, mise),
+// Homebrew and previous installations into temporary directories
+// by hc-install (releases & checkpoint sources without InstallDir)
+func managedDirs(productName string) []string {
+	if productName == "" {
+		return []string{}
+	}
+
+	patterns := make([]string, 0)
+
+	homeDir, _ := os.UserHomeDir()
+
+	if productName == "terraform" {
+		tfenvDir := firstNonEmpty(os.Getenv("TFENV_CONFIG_DIR"), os.Getenv("TFENV_ROOT"), joinHome(homeDir, ".tfenv"))
+		if tfenvDir != "" {
+			patterns = append(patterns, filepath.Join(tfenvDir, "versions", "*"))
+		}
+	}
+
+	asdfDir := firstNonEmpty(os.Getenv("ASDF_DATA_DIR"), joinHome(homeDir, ".asdf"))
+	if asdfDir != "" {
+		patterns = append(patterns, filepath.Join(asdfDir, "installs", productName, "*", "bin"))
+	}
+
+	miseDir := os.Getenv("MISE_DATA_DIR")
+	if miseDir == "" {
+		dataDir := firstNonEmpty(os.Getenv("XDG_DATA_HOME"), joinHome(homeDir, ".local", "share"))
+		if dataDir != "" {
+			miseDir = filepath.Join(dataDir, "mise")
+		}
+	}
+	if miseDir != "" {
+		patterns = append(patterns,
+			filepath.Join(miseDir, "installs", productName, "*"),
+			filepath.Join(miseDir, "installs", productName, "*", "bin"))
+	}
+
+	cellarDirs := []string{
+		os.Getenv("HOMEBREW_CELLAR"),
+		"/opt/homebrew/Cellar",
+		"/usr/local/Cellar",
+		"/home/linuxbrew/.linuxbrew/Cellar",
+	}
+	for _, cellarDir := range cellarDirs {
+		if cellarDir != "" {
+			patterns = append(patterns, filepath.Join(cellarDir, productName, "*", "bin"))
+		}
+	}
+
+	patterns = append(patterns, filepath.Join(os.TempDir(), productName+"_*"))
+
+	dirs := make([]string, 0)
+	seen := make(map[string]bool, 0)
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			continue
+		}
+		for _, dir := range matches {
+			if seen[dir] {
+				continue
+			}
+			seen[dir] = true
+			if fi, err := os.Stat(dir); err == nil && fi.IsDir() {
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	return dirs
+}
+
+// searchDirs returns directories to look up the product binary in,
+// i.e. $PATH, extraDirs and (if discovery is enabled) managed directories
+func searchDirs(productName string, extraDirs []string, discoverManaged bool) []string {
+	dirs := lookupDirs(extraDirs)
+	if discoverManaged {
+		dirs = append(dirs, managedDirs(productName)...)
+	}
+	return dirs
+}
+
+func joinHome(homeDir string, elem ...string) string {
+	if homeDir == "" {
+		return ""
+	}
+	return filepath.Join(append([]string{homeDir}, elem...)...)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -7,17 +7,18 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"path/filepath"
 	"time"
 
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hc-install/errors"
-	"github.com/hashicorp/hc-install/internal/src"
 	"github.com/hashicorp/hc-install/internal/validators"
+	"github.com/hashicorp/hc-install/internal/versioncheck"
+	"github.com/hashicorp/hc-install/policy"
 	"github.com/hashicorp/hc-install/product"
+	"github.com/hashicorp/hc-install/src"
 )
 
-// Version finds the first executable binary of the product name
+// Version finds an executable binary of the product name
 // which matches the version constraint within system $PATH and any declared ExtraPaths
 // (which are *appended* to any directories in $PATH)
 type Version struct {
@@ -26,7 +27,37 @@ type Version struct {
 	ExtraPaths  []string
 	Timeout     time.Duration
 
-	logger *log.Logger
+	// DiscoverManagedInstalls indicates that versioned installations
+	// managed by tfenv, asdf, mise, Homebrew (Cellar) and previous
+	// installations into temporary directories by hc-install
+	// should be looked up too (after $PATH and ExtraPaths)
+	DiscoverManagedInstalls bool
+
+	// Strategy determines which of the matching binaries is found,
+	// the first one in $PATH by default
+	Strategy SelectionStrategy
+
+	// PreferBuildInfo indicates that the version of found binaries should
+	// be read without executing them (via Product.ReadVersion, e.g. from
+	// Go build info), falling back to executing them via Product.GetVersion
+	PreferBuildInfo bool
+
+	// TrustReceipts indicates that the version recorded in the install
+	// receipt of a found binary (see package receipt) is used instead
+	// of detecting it, provided the binary matches the receipt
+	TrustReceipts bool
+
+	// VersionCache represents an optional on-disk cache of detected
+	// versions, so that unchanged binaries don't have to be executed again
+	VersionCache *VersionCache
+
+	// Policy represents an optional policy which has to allow
+	// the version of a binary for it to be found
+	Policy policy.Policy
+
+	logger          *log.Logger
+	artifact        *src.Artifact
+	installerPolicy policy.Policy
 }
 
 func (*Version) IsSourceImpl() src.InstallSrcSigil {
@@ -37,6 +68,24 @@ func (v *Version) SetLogger(logger *log.Logger) {
 	v.logger = logger
 }
 
+// SetPolicy sets a policy which is consulted in addition to Policy
+func (v *Version) SetPolicy(p policy.Policy) {
+	v.installerPolicy = p
+}
+
+func (v *Version) searchDirs() []string {
+	return searchDirs(v.Product.Name, v.ExtraPaths, v.DiscoverManagedInstalls)
+}
+
+func (v *Version) versionDetector() *versionDetector {
+	return &versionDetector{
+		product:           v.Product,
+		preferReadVersion: v.PreferBuildInfo,
+		trustReceipts:     v.TrustReceipts,
+		cache:             v.VersionCache,
+	}
+}
+
 func (v *Version) log() *log.Logger {
 	if v.logger == nil {
 		return discardLogger
@@ -45,19 +94,30 @@ func (v *Version) log() *log.Logger {
 }
 
 func (v *Version) Validate() error {
-	if !validators.IsBinaryNameValid(v.Product.BinaryName()) {
-		return fmt.Errorf("invalid binary name: %q", v.Product.BinaryName())
+	// name may depend on the version, which isn't known yet
+	if target := product.CurrentTarget(nil); v.Product.BinaryNameKnown(target) {
+		binaryName := v.Product.BinaryNameFor(target)
+		if !validators.IsBinaryNameValid(binaryName) {
+			return fmt.Errorf("invalid binary name: %q", binaryName)
+		}
 	}
 	if len(v.Constraints) == 0 {
 		return fmt.Errorf("undeclared version constraints")
 	}
-	if v.Product.GetVersion == nil {
-		return fmt.Errorf("undeclared version getter")
+	if err := versioncheck.Validate(v.Product, v.PreferBuildInfo); err != nil {
+		return err
+	}
+	if v.Strategy < SelectFirst || v.Strategy > SelectLowest {
+		return fmt.Errorf("unknown selection strategy: %s", v.Strategy)
 	}
 	return nil
 }
 
 func (v *Version) Find(ctx context.Context) (string, error) {
+	return v.find(ctx, v.versionDetector())
+}
+
+func (v *Version) find(ctx context.Context, vd *versionDetector) (string, error) {
 	timeout := defaultTimeout
 	if v.Timeout > 0 {
 		timeout = v.Timeout
@@ -65,36 +125,132 @@ func (v *Version) Find(ctx context.Context) (string, error) {
 	ctx, cancelFunc := context.WithTimeout(ctx, timeout)
 	defer cancelFunc()
 
-	execPath, err := findFile(lookupDirs(v.ExtraPaths), v.Product.BinaryName(), func(file string) error {
-		err := checkExecutable(file)
+	if v.Strategy != SelectFirst {
+		candidates, err := v.findAll(ctx, vd)
 		if err != nil {
-			return err
+			return "", errors.SkippableErr(err)
 		}
+		v.artifact = &src.Artifact{Version: candidates[0].Version}
+		return candidates[0].ExecPath, nil
+	}
 
-		ver, err := v.Product.GetVersion(ctx, file)
+	binaryName, err := binaryNameFor(v.Product, nil)
+	if err != nil {
+		return "", errors.SkippableErr(err)
+	}
+
+	var foundVersion *version.Version
+	execPath, err := findFile(v.searchDirs(), binaryName, func(file string) error {
+		ver, err := v.check(ctx, vd, file)
 		if err != nil {
 			return err
 		}
-
-		for _, vc := range v.Constraints {
-			if !vc.Check(ver) {
-				return fmt.Errorf("version (%s) doesn't meet constraints %s", ver, vc.String())
-			}
-		}
-
+		foundVersion = ver
 		return nil
 	})
 	if err != nil {
 		return "", errors.SkippableErr(err)
 	}
 
-	if !filepath.IsAbs(execPath) {
-		var err error
-		execPath, err = filepath.Abs(execPath)
-		if err != nil {
-			return "", errors.SkippableErr(err)
-		}
+	execPath, err = absPath(execPath)
+	if err != nil {
+		return "", errors.SkippableErr(err)
 	}
 
+	v.artifact = &src.Artifact{Version: foundVersion}
+
 	return execPath, nil
 }
+
+// FindAll finds all executable binaries matching the constraints
+// and returns them ranked according to Strategy
+func (v *Version) FindAll(ctx context.Context) ([]*Candidate, error) {
+	timeout := defaultTimeout
+	if v.Timeout > 0 {
+		timeout = v.Timeout
+	}
+	ctx, cancelFunc := context.WithTimeout(ctx, timeout)
+	defer cancelFunc()
+
+	candidates, err := v.findAll(ctx, v.versionDetector())
+	if err != nil {
+		return nil, errors.SkippableErr(err)
+	}
+	return candidates, nil
+}
+
+func (v *Version) findAll(ctx context.Context, vd *versionDetector) ([]*Candidate, error) {
+	binaryName, err := binaryNameFor(v.Product, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := findCandidates(v.searchDirs(), binaryName, func(file string) (*version.Version, error) {
+		return v.check(ctx, vd, file)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = rankCandidates(candidates, v.Strategy)
+	if err != nil {
+		return nil, err
+	}
+	v.log().Printf("found %d matching %s binaries, selecting %s (%s)",
+		len(candidates), v.Product.Name, candidates[0].ExecPath, candidates[0].Version)
+
+	return candidates, nil
+}
+
+// check returns the version of the binary if it is executable,
+// meets the constraints and is allowed by policies
+func (v *Version) check(ctx context.Context, vd *versionDetector, file string) (*version.Version, error) {
+	err := checkExecutable(file)
+	if err != nil {
+		return nil, err
+	}
+
+	ver, err := vd.version(ctx, v.log(), file)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, vc := range v.Constraints {
+		if !vc.Check(ver) {
+			return nil, fmt.Errorf("version (%s) doesn't meet constraints %s", ver, vc.String())
+		}
+	}
+
+	err = policy.Policies{v.Policy, v.installerPolicy}.Check(ctx, &policy.Candidate{
+		Product: v.Product.Name,
+		Version: ver,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ver, nil
+}
+
+// Artifact returns details of the binary found by the last
+// successful Find, or nil if nothing was found yet
+func (v *Version) Artifact() *src.Artifact {
+	return v.artifact
+}
+
+// Resolve determines which binary would be found,
+// which is the same as Find, except that detected
+// versions are not stored in VersionCache
+func (v *Version) Resolve(ctx context.Context) (*src.Resolution, error) {
+	vd := v.versionDetector()
+	vd.readOnlyCache = true
+
+	execPath, err := v.find(ctx, vd)
+	if err != nil {
+		return nil, err
+	}
+	return &src.Resolution{
+		Version:  v.artifact.Version,
+		ExecPath: execPath,
+	}, nil
+}
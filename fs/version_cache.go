@@ -0,0 +1,155 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package fs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/internal/atomicfile"
+)
+
+// VersionCache caches detected versions of binaries on disk, such that
+// binaries don't have to be executed (or read) again to find out their
+// version. Cached versions are keyed by path and invalidated whenever
+// the size, modification time or inode of the file changes.
+//
+// The cache may be shared by multiple processes.
+type VersionCache struct {
+	// Dir represents the directory where the versions are cached
+	Dir string
+}
+
+type versionCacheEntry struct {
+	Product string `json:"product"`
+	Path    string `json:"path"`
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"mod_time"`
+	Inode   uint64 `json:"inode,omitempty"`
+	Version string `json:"version"`
+}
+
+// version returns the cached version of the binary at path,
+// or detects it via detectFunc and caches it
+func (vc *VersionCache) version(logger *log.Logger, productName, path string,
+	detectFunc func() (*version.Version, error)) (*version.Version, error) {
+	v, current := vc.lookup(logger, productName, path)
+	if v != nil {
+		return v, nil
+	}
+
+	v, err := detectFunc()
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return v, nil
+	}
+
+	// file details are from before detection, so that any change
+	// in the meantime invalidates the entry
+	current.Version = v.Original()
+	err = vc.store(current)
+	if err != nil {
+		logger.Printf("failed to cache version of %s: %s", current.Path, err)
+	}
+
+	return v, nil
+}
+
+// lookup returns the cached version of the binary at path, if any
+// is still valid, along with current details of the file (if known).
+// It never writes to the cache.
+func (vc *VersionCache) lookup(logger *log.Logger, productName, path string) (*version.Version, *versionCacheEntry) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, nil
+	}
+
+	fi, err := os.Stat(absPath)
+	if err != nil {
+		return nil, nil
+	}
+	current := &versionCacheEntry{
+		Product: productName,
+		Path:    absPath,
+		Size:    fi.Size(),
+		ModTime: fi.ModTime().UnixNano(),
+		Inode:   fileID(fi),
+	}
+
+	entry, err := vc.load(productName, absPath)
+	if err != nil {
+		// a broken cache shouldn't prevent detecting the version
+		logger.Printf("ignoring cached version of %s: %s", absPath, err)
+		return nil, current
+	}
+	if entry == nil || !entry.matches(current) {
+		return nil, current
+	}
+
+	v, err := version.NewVersion(entry.Version)
+	if err != nil {
+		logger.Printf("ignoring cached version of %s: %s", absPath, err)
+		return nil, current
+	}
+	logger.Printf("using cached version of %s: %s", absPath, v)
+	return v, current
+}
+
+func (e *versionCacheEntry) matches(other *versionCacheEntry) bool {
+	return e.Product == other.Product &&
+		e.Path == other.Path &&
+		e.Size == other.Size &&
+		e.ModTime == other.ModTime &&
+		e.Inode == other.Inode
+}
+
+func (vc *VersionCache) load(productName, path string) (*versionCacheEntry, error) {
+	b, err := os.ReadFile(vc.entryPath(productName, path))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entry := &versionCacheEntry{}
+	err = json.Unmarshal(b, entry)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Path != path {
+		return nil, fmt.Errorf("unexpected path %q", entry.Path)
+	}
+
+	return entry, nil
+}
+
+func (vc *VersionCache) store(entry *versionCacheEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(vc.Dir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	return atomicfile.Write(vc.entryPath(entry.Product, entry.Path), b)
+}
+
+func (vc *VersionCache) entryPath(productName, path string) string {
+	sum := sha256.Sum256([]byte(productName + "\x00" + path))
+	return filepath.Join(vc.Dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,85 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/internal/testutil"
+)
+
+func TestVersionCache(t *testing.T) {
+	t.Parallel()
+
+	logger := testutil.TestLogger()
+	cacheDir := t.TempDir()
+	path := filepath.Join(t.TempDir(), "terraform")
+	err := os.WriteFile(path, []byte("1.5.0"), 0o700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	detections := 0
+	detectFunc := func() (*version.Version, error) {
+		detections++
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return version.NewVersion(string(b))
+	}
+
+	assertVersion := func(vc *VersionCache, expectedVersion string, expectedDetections int) {
+		t.Helper()
+		v, err := vc.version(logger, "terraform", path, detectFunc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.String() != expectedVersion {
+			t.Fatalf("expected version %s, got %s", expectedVersion, v)
+		}
+		if detections != expectedDetections {
+			t.Fatalf("expected %d detections, got %d", expectedDetections, detections)
+		}
+	}
+
+	vc := &VersionCache{Dir: cacheDir}
+	assertVersion(vc, "1.5.0", 1)
+	assertVersion(vc, "1.5.0", 1)
+
+	// another instance (e.g. in another process) uses the same entries
+	assertVersion(&VersionCache{Dir: cacheDir}, "1.5.0", 1)
+
+	// changed file invalidates the entry
+	err = os.WriteFile(path, []byte("1.10.0"), 0o700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertVersion(vc, "1.10.0", 2)
+	assertVersion(vc, "1.10.0", 2)
+
+	// the same file is cached separately per product
+	_, err = vc.version(logger, "vault", path, detectFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detections != 3 {
+		t.Fatalf("expected 3 detections, got %d", detections)
+	}
+
+	// broken entry is ignored and replaced
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(vc.entryPath("terraform", absPath), []byte("{"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertVersion(vc, "1.10.0", 4)
+	assertVersion(vc, "1.10.0", 4)
+}
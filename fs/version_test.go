@@ -41,6 +41,14 @@ func TestVersionValidate(t *testing.T) {
 			},
 			expectedErr: fmt.Errorf("undeclared version constraints"),
 		},
+		"unknown-strategy": {
+			v: Version{
+				Product:     product.Terraform,
+				Constraints: version.MustConstraints(version.NewConstraint(">= 1.0")),
+				Strategy:    SelectionStrategy(42),
+			},
+			expectedErr: fmt.Errorf("unknown selection strategy: SelectionStrategy(42)"),
+		},
 		"Product-and-version-constraint": {
 			v: Version{
 				Product:     product.Terraform,
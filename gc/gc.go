@@ -0,0 +1,167 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package gc removes stale temporary directories created by hc-install,
+// e.g. by sources which were never removed via Installer.Remove
+// because the process crashed.
+package gc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/hc-install/internal/tempdir"
+)
+
+// DefaultMinAge represents how old a temporary directory has to be
+// by default to be removed
+const DefaultMinAge = 24 * time.Hour
+
+var discardLogger = log.New(io.Discard, "", 0)
+
+// Collector finds temporary directories created by hc-install,
+// which are marked by a file identifying the process which created
+// them, and removes those which are older than MinAge and no longer
+// in use. Processes hold a lock on the marker while using the directory,
+// such that directories of processes on other hosts sharing Dir
+// (e.g. via NFS) are kept too.
+type Collector struct {
+	// Dir represents the directory to look for temporary directories in,
+	// defaulting to the default directory for temporary files
+	Dir string
+
+	// MinAge represents how old a directory has to be to be removed,
+	// defaulting to DefaultMinAge
+	MinAge time.Duration
+
+	// DryRun indicates that stale directories are only reported
+	DryRun bool
+
+	logger *log.Logger
+}
+
+// Entry describes a temporary directory created by hc-install
+type Entry struct {
+	Path      string
+	CreatedAt time.Time
+
+	// PID and Hostname identify the process which created the directory
+	PID      int
+	Hostname string
+
+	// Size is the total size of files within the directory in bytes
+	Size int64
+
+	// Locked indicates that the directory is still in use, i.e. its
+	// marker is locked (or the lock could not be determined)
+	Locked bool
+
+	// Stale indicates that the directory is older than MinAge and not locked
+	Stale bool
+
+	// Removed indicates that the stale directory was removed,
+	// Err describes why it could not be removed
+	Removed bool
+	Err     error
+}
+
+func (c *Collector) SetLogger(logger *log.Logger) {
+	c.logger = logger
+}
+
+func (c *Collector) log() *log.Logger {
+	if c.logger == nil {
+		return discardLogger
+	}
+	return c.logger
+}
+
+// Collect finds all temporary directories created by hc-install
+// and removes stale ones (unless DryRun is set). Failures to remove
+// individual directories are reported via Entry.Err.
+func (c *Collector) Collect(ctx context.Context) ([]*Entry, error) {
+	dir := c.Dir
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	minAge := DefaultMinAge
+	if c.MinAge > 0 {
+		minAge = c.MinAge
+	}
+
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entries := make([]*Entry, 0)
+	for _, de := range dirEntries {
+		if err := ctx.Err(); err != nil {
+			return entries, err
+		}
+		if !de.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(dir, de.Name())
+		m, err := tempdir.ReadMarker(path)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				c.log().Printf("ignoring %s with invalid marker: %s", path, err)
+			}
+			continue
+		}
+
+		locked, err := tempdir.Locked(path)
+		if err != nil {
+			c.log().Printf("unable to check lock of %s: %s", path, err)
+			locked = true
+		}
+
+		e := &Entry{
+			Path:      path,
+			CreatedAt: m.CreatedAt,
+			PID:       m.PID,
+			Hostname:  m.Hostname,
+			Size:      dirSize(path),
+			Locked:    locked,
+		}
+		e.Stale = !e.Locked && now.Sub(m.CreatedAt) >= minAge
+		entries = append(entries, e)
+
+		if !e.Stale || c.DryRun {
+			c.log().Printf("keeping %s (created at %s by PID %d, locked: %t, stale: %t)",
+				path, e.CreatedAt, e.PID, e.Locked, e.Stale)
+			continue
+		}
+
+		c.log().Printf("removing stale %s (created at %s by PID %d)", path, e.CreatedAt, e.PID)
+		e.Err = os.RemoveAll(path)
+		e.Removed = e.Err == nil
+	}
+
+	return entries, nil
+}
+
+func dirSize(path string) int64 {
+	var size int64
+	filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.Type().IsRegular() {
+			if fi, err := d.Info(); err == nil {
+				size += fi.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
@@ -0,0 +1,141 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package gc
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hc-install/internal/tempdir"
+	"github.com/hashicorp/hc-install/internal/testutil"
+)
+
+func TestCollector_Collect(t *testing.T) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-48 * time.Hour)
+
+	dir := t.TempDir()
+	t.Setenv("TMPDIR", dir)
+	t.Setenv("TMP", dir)
+
+	// directory in use, e.g. by a process on another host sharing dir
+	lockedDir, err := tempdir.MkdirTemp("terraform_locked")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { tempdir.RemoveAll(lockedDir) })
+	writeMarkedDir(t, lockedDir, &tempdir.Marker{PID: 1, Hostname: "other-" + hostname, CreatedAt: old})
+	lockedName := filepath.Base(lockedDir)
+
+	markers := map[string]*tempdir.Marker{
+		// PID may have been reused by an unrelated process
+		"terraform_stale":      {PID: os.Getpid(), Hostname: hostname, CreatedAt: old},
+		"terraform_recent":     {PID: os.Getpid(), Hostname: hostname, CreatedAt: time.Now()},
+		"terraform_other_host": {PID: 1, Hostname: "other-" + hostname, CreatedAt: old},
+	}
+	for name, m := range markers {
+		writeMarkedDir(t, filepath.Join(dir, name), m)
+	}
+	err = os.Mkdir(filepath.Join(dir, "unmarked"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Collector{Dir: dir, DryRun: true}
+	c.SetLogger(testutil.TestLogger())
+
+	entries, err := c.Collect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEntries(t, entries, map[string]bool{
+		lockedName:             false,
+		"terraform_stale":      true,
+		"terraform_recent":     false,
+		"terraform_other_host": true,
+	}, false)
+
+	c.DryRun = false
+	entries, err = c.Collect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEntries(t, entries, map[string]bool{
+		lockedName:             false,
+		"terraform_stale":      true,
+		"terraform_recent":     false,
+		"terraform_other_host": true,
+	}, true)
+
+	remaining, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0)
+	for _, de := range remaining {
+		names = append(names, de.Name())
+	}
+	expectedNames := []string{lockedName, "terraform_recent", "unmarked"}
+	if len(names) != len(expectedNames) {
+		t.Fatalf("expected remaining dirs %q, got %q", expectedNames, names)
+	}
+	for i := range names {
+		if names[i] != expectedNames[i] {
+			t.Fatalf("expected remaining dirs %q, got %q", expectedNames, names)
+		}
+	}
+}
+
+func assertEntries(t *testing.T, entries []*Entry, expectedStale map[string]bool, expectRemoval bool) {
+	t.Helper()
+
+	if len(entries) != len(expectedStale) {
+		t.Fatalf("expected %d entries, got %d", len(expectedStale), len(entries))
+	}
+	for _, e := range entries {
+		name := filepath.Base(e.Path)
+		stale, ok := expectedStale[name]
+		if !ok {
+			t.Fatalf("unexpected entry: %q", e.Path)
+		}
+		if e.Stale != stale {
+			t.Fatalf("expected %s stale: %t, got %t", name, stale, e.Stale)
+		}
+		if e.Err != nil {
+			t.Fatalf("unexpected error for %s: %s", name, e.Err)
+		}
+		if e.Removed != (stale && expectRemoval) {
+			t.Fatalf("expected %s removed: %t, got %t", name, stale && expectRemoval, e.Removed)
+		}
+		if e.Size <= 6 {
+			t.Fatalf("expected %s to have more than 6 bytes of files, got %d", name, e.Size)
+		}
+	}
+}
+
+func writeMarkedDir(t *testing.T, path string, m *tempdir.Marker) {
+	err := os.MkdirAll(path, 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(path, tempdir.MarkerFileName), b, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(path, "binary"), []byte("binary"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -15,7 +15,9 @@ require (
 	github.com/hashicorp/go-retryablehttp v0.7.8
 	github.com/hashicorp/go-version v1.9.0
 	github.com/hashicorp/logutils v1.0.0
+	github.com/transparency-dev/merkle v0.0.2
 	golang.org/x/mod v0.40.0
+	golang.org/x/sys v0.47.0
 )
 
 require (
@@ -55,6 +57,5 @@ require (
 	github.com/xanzy/ssh-agent v0.3.3 // indirect
 	golang.org/x/crypto v0.55.0 // indirect
 	golang.org/x/net v0.57.0 // indirect
-	golang.org/x/sys v0.47.0 // indirect
 	gopkg.in/warnings.v0 v0.1.2 // indirect
 )
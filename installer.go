@@ -8,14 +8,19 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/hc-install/errors"
+	"github.com/hashicorp/hc-install/policy"
+	"github.com/hashicorp/hc-install/registry"
 	"github.com/hashicorp/hc-install/src"
 )
 
 type Installer struct {
-	logger *log.Logger
+	logger   *log.Logger
+	policy   policy.Policy
+	registry *registry.Registry
 
 	removableSources []src.Removable
 }
@@ -33,90 +38,230 @@ func (i *Installer) SetLogger(logger *log.Logger) {
 	i.logger = logger
 }
 
+// SetPolicy sets a policy which is set on all sources implementing
+// policy.Settable, such that versions denied by the policy are
+// skipped during version selection and never downloaded. Sources
+// which don't implement it (e.g. fs.AnyVersion) are not restricted.
+func (i *Installer) SetPolicy(p policy.Policy) {
+	i.policy = p
+}
+
+// SetRegistry sets a registry which is set on all sources implementing
+// registry.Settable, such that files they install are recorded
+// and can be removed via registry.Registry.Remove by another process.
+// Sources which don't implement it (e.g. build.GitRevision) are not recorded.
+func (i *Installer) SetRegistry(r *registry.Registry) {
+	i.registry = r
+}
+
+// Ensure finds, installs, or builds a product version using the first
+// source which succeeds and returns the path to the binary
 func (i *Installer) Ensure(ctx context.Context, sources []src.Source) (string, error) {
+	result, err := i.EnsureWithResult(ctx, sources)
+	if err != nil {
+		return "", err
+	}
+	return result.ExecPath, nil
+}
+
+// EnsureWithResult is like Ensure, but describes the outcome in detail,
+// including which source won and why any earlier sources were skipped
+func (i *Installer) EnsureWithResult(ctx context.Context, sources []src.Source) (*InstallResult, error) {
+	err := i.prepareSources(sources)
+	if err != nil {
+		return nil, err
+	}
+
 	var errs *multierror.Error
 
+	i.removableSources = make([]src.Removable, 0)
+
+	startedAt := time.Now()
+	skipped := make([]*SkippedSource, 0)
+
 	for _, source := range sources {
-		if srcWithLogger, ok := source.(src.LoggerSettable); ok {
-			srcWithLogger.SetLogger(i.logger)
+		if s, ok := source.(src.Removable); ok {
+			i.removableSources = append(i.removableSources, s)
 		}
 
-		if srcValidatable, ok := source.(src.Validatable); ok {
-			err := srcValidatable.Validate()
-			if err != nil {
+		var (
+			execPath string
+			origin   Origin
+			err      error
+		)
+
+		switch s := source.(type) {
+		case src.Findable:
+			origin = OriginFound
+			execPath, err = s.Find(ctx)
+		case src.Installable:
+			origin = OriginInstalled
+			execPath, err = s.Install(ctx)
+		case src.Buildable:
+			origin = OriginBuilt
+			execPath, err = s.Build(ctx)
+		default:
+			return nil, fmt.Errorf("unknown source: %T", s)
+		}
+		if err != nil {
+			if isSkippable(err) {
 				errs = multierror.Append(errs, err)
+				skipped = append(skipped, &SkippedSource{Source: source, Err: err})
+				continue
 			}
+			return nil, err
 		}
+
+		result := newInstallResult(source, origin, execPath)
+		result.StartedAt = startedAt
+		result.Duration = time.Since(startedAt)
+		result.SkippedSources = skipped
+		return result, nil
 	}
 
-	if errs.ErrorOrNil() != nil {
-		return "", errs
+	return nil, fmt.Errorf("unable to find, install, or build from %d sources: %w",
+		len(sources), errs.ErrorOrNil())
+}
+
+// Plan determines what Ensure would do with the given sources, without
+// downloading or building anything. Sources are resolved in order, such
+// that the plan describes the first source which would succeed and why
+// any earlier sources would be skipped.
+//
+// Sources which don't implement src.Resolvable can't be resolved upfront.
+// The plan then describes such source with no Resolution, as it would
+// be attempted by Ensure, with the outcome unknown.
+func (i *Installer) Plan(ctx context.Context, sources []src.Source) (*InstallPlan, error) {
+	err := i.prepareSources(sources)
+	if err != nil {
+		return nil, err
 	}
 
-	i.removableSources = make([]src.Removable, 0)
+	var errs *multierror.Error
+	skipped := make([]*SkippedSource, 0)
 
 	for _, source := range sources {
-		if s, ok := source.(src.Removable); ok {
-			i.removableSources = append(i.removableSources, s)
+		origin, ok := sourceOrigin(source)
+		if !ok {
+			return nil, fmt.Errorf("unknown source: %T", source)
 		}
 
-		switch s := source.(type) {
-		case src.Findable:
-			execPath, err := s.Find(ctx)
-			if err != nil {
-				if errors.IsErrorSkippable(err) {
-					errs = multierror.Append(errs, err)
-					continue
-				}
-				return "", err
-			}
+		plan := &InstallPlan{
+			Source:         source,
+			Origin:         origin,
+			SkippedSources: skipped,
+		}
 
-			return execPath, nil
-		case src.Installable:
-			execPath, err := s.Install(ctx)
-			if err != nil {
-				if errors.IsErrorSkippable(err) {
-					errs = multierror.Append(errs, err)
-					continue
-				}
-				return "", err
+		rs, ok := source.(src.Resolvable)
+		if !ok {
+			return plan, nil
+		}
+
+		plan.Resolution, err = rs.Resolve(ctx)
+		if err != nil {
+			if isSkippable(err) {
+				errs = multierror.Append(errs, err)
+				skipped = append(skipped, &SkippedSource{Source: source, Err: err})
+				continue
 			}
+			return nil, err
+		}
 
-			return execPath, nil
-		case src.Buildable:
-			execPath, err := s.Build(ctx)
+		return plan, nil
+	}
+
+	return nil, fmt.Errorf("unable to find, install, or build from %d sources: %w",
+		len(sources), errs.ErrorOrNil())
+}
+
+// prepareSources sets the logger on and validates all sources
+func (i *Installer) prepareSources(sources []src.Source) error {
+	var errs *multierror.Error
+
+	for _, source := range sources {
+		if srcWithLogger, ok := source.(src.LoggerSettable); ok {
+			srcWithLogger.SetLogger(i.logger)
+		}
+
+		i.setPolicy(source)
+		i.setRegistry(source)
+
+		if srcValidatable, ok := source.(src.Validatable); ok {
+			err := srcValidatable.Validate()
 			if err != nil {
-				if errors.IsErrorSkippable(err) {
-					errs = multierror.Append(errs, err)
-					continue
-				}
-				return "", err
+				errs = multierror.Append(errs, err)
 			}
-
-			return execPath, nil
-		default:
-			return "", fmt.Errorf("unknown source: %T", s)
 		}
 	}
 
-	return "", fmt.Errorf("unable to find, install, or build from %d sources: %s",
-		len(sources), errs.ErrorOrNil())
+	return errs.ErrorOrNil()
+}
+
+func (i *Installer) setPolicy(source src.Source) {
+	if i.policy == nil {
+		return
+	}
+	if srcWithPolicy, ok := source.(policy.Settable); ok {
+		srcWithPolicy.SetPolicy(i.policy)
+	}
+}
+
+func (i *Installer) setRegistry(source src.Source) {
+	if i.registry == nil {
+		return
+	}
+	if srcWithRegistry, ok := source.(registry.Settable); ok {
+		srcWithRegistry.SetRegistry(i.registry)
+	}
+}
+
+// sourceOrigin returns how the source obtains the binary,
+// in the same order of precedence as used by Ensure
+func sourceOrigin(source src.Source) (Origin, bool) {
+	switch source.(type) {
+	case src.Findable:
+		return OriginFound, true
+	case src.Installable:
+		return OriginInstalled, true
+	case src.Buildable:
+		return OriginBuilt, true
+	}
+	return "", false
 }
 
+// Install installs a product version using the first
+// source which succeeds and returns the path to the binary
 func (i *Installer) Install(ctx context.Context, sources []src.Installable) (string, error) {
+	result, err := i.InstallWithResult(ctx, sources)
+	if err != nil {
+		return "", err
+	}
+	return result.ExecPath, nil
+}
+
+// InstallWithResult is like Install, but describes the outcome in detail,
+// including which source won and why any earlier sources were skipped
+func (i *Installer) InstallWithResult(ctx context.Context, sources []src.Installable) (*InstallResult, error) {
 	var errs *multierror.Error
 
 	i.removableSources = make([]src.Removable, 0)
 
+	startedAt := time.Now()
+	skipped := make([]*SkippedSource, 0)
+
 	for _, source := range sources {
 		if srcWithLogger, ok := source.(src.LoggerSettable); ok {
 			srcWithLogger.SetLogger(i.logger)
 		}
 
+		i.setPolicy(source)
+		i.setRegistry(source)
+
 		if srcValidatable, ok := source.(src.Validatable); ok {
 			err := srcValidatable.Validate()
 			if err != nil {
 				errs = multierror.Append(errs, err)
+				skipped = append(skipped, &SkippedSource{Source: source, Err: err})
 				continue
 			}
 		}
@@ -127,17 +272,22 @@ func (i *Installer) Install(ctx context.Context, sources []src.Installable) (str
 
 		execPath, err := source.Install(ctx)
 		if err != nil {
-			if errors.IsErrorSkippable(err) {
+			if isSkippable(err) {
 				errs = multierror.Append(errs, err)
+				skipped = append(skipped, &SkippedSource{Source: source, Err: err})
 				continue
 			}
-			return "", err
+			return nil, err
 		}
 
-		return execPath, nil
+		result := newInstallResult(source, OriginInstalled, execPath)
+		result.StartedAt = startedAt
+		result.Duration = time.Since(startedAt)
+		result.SkippedSources = skipped
+		return result, nil
 	}
 
-	return "", fmt.Errorf("unable install from %d sources: %s",
+	return nil, fmt.Errorf("unable install from %d sources: %w",
 		len(sources), errs.ErrorOrNil())
 }
 
@@ -155,3 +305,35 @@ func (i *Installer) Remove(ctx context.Context) error {
 
 	return errs.ErrorOrNil()
 }
+
+// PlanRemove describes what Remove would do, without removing anything.
+// Sources which don't implement registry.RemovalPlanner are not described.
+func (i *Installer) PlanRemove(ctx context.Context) (*registry.RemovalPlan, error) {
+	plan := &registry.RemovalPlan{
+		Remove:  make([]string, 0),
+		Restore: make([]string, 0),
+		Keep:    make([]*registry.KeptPath, 0),
+	}
+
+	for _, rs := range i.removableSources {
+		rp, ok := rs.(registry.RemovalPlanner)
+		if !ok {
+			continue
+		}
+		sp, err := rp.PlanRemove(ctx)
+		if err != nil {
+			return nil, err
+		}
+		plan.Remove = append(plan.Remove, sp.Remove...)
+		plan.Restore = append(plan.Restore, sp.Restore...)
+		plan.Keep = append(plan.Keep, sp.Keep...)
+	}
+
+	return plan, nil
+}
+
+// isSkippable returns true if the next source should be attempted
+// after the error, which includes versions denied by a policy
+func isSkippable(err error) bool {
+	return errors.IsErrorSkippable(err) || policy.IsDenied(err)
+}
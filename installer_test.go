@@ -5,6 +5,7 @@ package install_test
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -12,8 +13,10 @@ import (
 
 	"github.com/hashicorp/go-version"
 	install "github.com/hashicorp/hc-install"
+	"github.com/hashicorp/hc-install/errors"
 	"github.com/hashicorp/hc-install/fs"
 	"github.com/hashicorp/hc-install/internal/testutil"
+	"github.com/hashicorp/hc-install/policy"
 	"github.com/hashicorp/hc-install/product"
 	"github.com/hashicorp/hc-install/releases"
 	"github.com/hashicorp/hc-install/src"
@@ -77,6 +80,321 @@ func TestInstaller_Ensure_findable(t *testing.T) {
 	}
 }
 
+// customSource represents a source implemented outside of hc-install
+type customSource struct {
+	execPath string
+	err      error
+	removed  bool
+}
+
+var (
+	_ src.Installable = &customSource{}
+	_ src.Removable   = &customSource{}
+)
+
+func (*customSource) IsSourceImpl() src.InstallSrcSigil {
+	return src.InstallSrcSigil{}
+}
+
+func (cs *customSource) Install(ctx context.Context) (string, error) {
+	return cs.execPath, cs.err
+}
+
+func (cs *customSource) Remove(ctx context.Context) error {
+	cs.removed = true
+	return nil
+}
+
+func TestInstaller_Ensure_customSource(t *testing.T) {
+	t.Parallel()
+
+	skipped := &customSource{
+		err: errors.SkippableErr(fmt.Errorf("not available")),
+	}
+	installed := &customSource{
+		execPath: "/opt/terraform",
+	}
+
+	i := install.NewInstaller()
+	ctx := context.Background()
+	execPath, err := i.Ensure(ctx, []src.Source{skipped, installed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if execPath != installed.execPath {
+		t.Fatalf("expected path %q, got %q", installed.execPath, execPath)
+	}
+
+	err = i.Remove(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !skipped.removed || !installed.removed {
+		t.Fatal("expected custom sources to be removed")
+	}
+}
+
+func TestInstaller_EnsureWithResult(t *testing.T) {
+	mockApiRoot := filepath.Join("releases", "testdata", "mock_api_tf_0_14_with_prereleases")
+	pubKey, err := os.ReadFile(filepath.Join("releases", "testdata", "2FCA0A85.pub"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := version.Must(version.NewVersion("0.14.11"))
+	fsSource := &fs.ExactVersion{
+		Product:    product.Terraform,
+		Version:    v,
+		ExtraPaths: []string{t.TempDir()},
+	}
+	releasesSource := &releases.ExactVersion{
+		Product:          product.Terraform,
+		Version:          v,
+		ArmoredPublicKey: string(pubKey),
+		ApiBaseURL:       testutil.NewTestServer(t, mockApiRoot).URL,
+	}
+
+	t.Setenv("PATH", "")
+
+	i := install.NewInstaller()
+	i.SetLogger(testutil.TestLogger())
+	ctx := context.Background()
+	result, err := i.EnsureWithResult(ctx, []src.Source{fsSource, releasesSource})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { i.Remove(ctx) })
+
+	if result.Source != releasesSource {
+		t.Fatalf("unexpected source: %#v", result.Source)
+	}
+	if result.Origin != install.OriginInstalled {
+		t.Fatalf("unexpected origin: %q", result.Origin)
+	}
+	if !result.Version.Equal(v) {
+		t.Fatalf("unexpected version: %s", result.Version)
+	}
+	if result.SHA256 == "" || result.SigningKeyID == "" {
+		t.Fatalf("expected checksum and signing key, given: %#v", result)
+	}
+	if _, err := os.Stat(result.ExecPath); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.SkippedSources) != 1 {
+		t.Fatalf("expected 1 skipped source, %d given", len(result.SkippedSources))
+	}
+	if result.SkippedSources[0].Source != fsSource {
+		t.Fatalf("unexpected skipped source: %#v", result.SkippedSources[0].Source)
+	}
+	if !errors.IsErrorSkippable(result.SkippedSources[0].Err) {
+		t.Fatalf("expected skippable error, given: %s", result.SkippedSources[0].Err)
+	}
+}
+
+func TestInstaller_Ensure_targetBinaryName(t *testing.T) {
+	mockApiRoot := filepath.Join("releases", "testdata", "mock_api_tf_0_14_with_prereleases")
+	pubKey, err := os.ReadFile(filepath.Join("releases", "testdata", "2FCA0A85.pub"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// name depends on the version, so it can't be determined
+	// (and the func panics) until the version is known
+	p := product.Product{
+		Name: product.Terraform.Name,
+		TargetBinaryName: func(t product.Target) string {
+			if t.Version.Prerelease() != "" {
+				return "terraform-" + t.Version.Prerelease()
+			}
+			return "terraform"
+		},
+		GetVersion: product.Terraform.GetVersion,
+	}
+	fsSource := &fs.Version{
+		Product:     p,
+		Constraints: version.MustConstraints(version.NewConstraint("~> 0.14")),
+		ExtraPaths:  []string{t.TempDir()},
+	}
+	releasesSource := &releases.LatestVersion{
+		Product:          p,
+		Constraints:      version.MustConstraints(version.NewConstraint("~> 0.14")),
+		ArmoredPublicKey: string(pubKey),
+		ApiBaseURL:       testutil.NewTestServer(t, mockApiRoot).URL,
+	}
+
+	t.Setenv("PATH", "")
+
+	i := install.NewInstaller()
+	i.SetLogger(testutil.TestLogger())
+	ctx := context.Background()
+	result, err := i.EnsureWithResult(ctx, []src.Source{fsSource, releasesSource})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { i.Remove(ctx) })
+
+	if result.Source != releasesSource {
+		t.Fatalf("unexpected source: %#v", result.Source)
+	}
+	if result.Version.String() != "0.14.11" {
+		t.Fatalf("unexpected version: %s", result.Version)
+	}
+	if filepath.Base(result.ExecPath) != "terraform" {
+		t.Fatalf("unexpected exec path: %q", result.ExecPath)
+	}
+	if len(result.SkippedSources) != 1 || result.SkippedSources[0].Source != fsSource {
+		t.Fatalf("expected fs source to be skipped, given: %#v", result.SkippedSources)
+	}
+	if !errors.IsErrorSkippable(result.SkippedSources[0].Err) {
+		t.Fatalf("expected skippable error, given: %s", result.SkippedSources[0].Err)
+	}
+}
+
+func TestInstaller_Plan(t *testing.T) {
+	mockApiRoot := filepath.Join("releases", "testdata", "mock_api_tf_0_14_with_prereleases")
+	apiBaseURL := testutil.NewTestServer(t, mockApiRoot).URL
+
+	t.Setenv("PATH", "")
+
+	fsSource := &fs.Version{
+		Product:     product.Terraform,
+		Constraints: version.MustConstraints(version.NewConstraint("~> 0.14")),
+		ExtraPaths:  []string{t.TempDir()},
+	}
+	releasesSource := &releases.LatestVersion{
+		Product:     product.Terraform,
+		Constraints: version.MustConstraints(version.NewConstraint("~> 0.14")),
+		ApiBaseURL:  apiBaseURL,
+	}
+	custom := &customSource{execPath: "/opt/terraform"}
+
+	i := install.NewInstaller()
+	i.SetLogger(testutil.TestLogger())
+	ctx := context.Background()
+
+	plan, err := i.Plan(ctx, []src.Source{fsSource, releasesSource, custom})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Source != releasesSource {
+		t.Fatalf("unexpected source: %#v", plan.Source)
+	}
+	if plan.Origin != install.OriginInstalled {
+		t.Fatalf("unexpected origin: %q", plan.Origin)
+	}
+	if plan.Resolution.Version.String() != "0.14.11" {
+		t.Fatalf("unexpected version: %s", plan.Resolution.Version)
+	}
+	if plan.Resolution.ArchiveURL == "" {
+		t.Fatal("expected archive URL")
+	}
+	if len(plan.SkippedSources) != 1 || plan.SkippedSources[0].Source != fsSource {
+		t.Fatalf("expected fs source to be skipped, given: %#v", plan.SkippedSources)
+	}
+
+	// sources which can't be resolved upfront are planned as attempted
+	plan, err = i.Plan(ctx, []src.Source{fsSource, custom})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Source != custom {
+		t.Fatalf("unexpected source: %#v", plan.Source)
+	}
+	if plan.Resolution != nil {
+		t.Fatalf("expected no resolution, given: %#v", plan.Resolution)
+	}
+}
+
+func TestInstaller_Plan_versionCache(t *testing.T) {
+	binDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(binDir, product.Terraform.BinaryName()), []byte("0.14.11"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+
+	t.Setenv("PATH", "")
+
+	p := product.Terraform
+	p.GetVersion = func(ctx context.Context, execPath string) (*version.Version, error) {
+		b, err := os.ReadFile(execPath)
+		if err != nil {
+			return nil, err
+		}
+		return version.NewVersion(string(b))
+	}
+	fsSource := &fs.Version{
+		Product:      p,
+		Constraints:  version.MustConstraints(version.NewConstraint("~> 0.14")),
+		ExtraPaths:   []string{binDir},
+		VersionCache: &fs.VersionCache{Dir: cacheDir},
+	}
+
+	i := install.NewInstaller()
+	i.SetLogger(testutil.TestLogger())
+	ctx := context.Background()
+
+	plan, err := i.Plan(ctx, []src.Source{fsSource})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Origin != install.OriginFound {
+		t.Fatalf("unexpected origin: %q", plan.Origin)
+	}
+	if plan.Resolution.Version.String() != "0.14.11" {
+		t.Fatalf("unexpected version: %s", plan.Resolution.Version)
+	}
+	if _, err := os.Stat(cacheDir); !os.IsNotExist(err) {
+		t.Fatalf("expected planning to leave version cache untouched, given: %v", err)
+	}
+
+	// whereas finding the binary caches its version
+	_, err = i.Ensure(ctx, []src.Source{fsSource})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 cached version, given: %d", len(entries))
+	}
+}
+
+func TestInstaller_SetPolicy(t *testing.T) {
+	mockApiRoot := filepath.Join("releases", "testdata", "mock_api_tf_0_14_with_prereleases")
+
+	releasesSource := &releases.ExactVersion{
+		Product:    product.Terraform,
+		Version:    version.Must(version.NewVersion("0.14.11")),
+		ApiBaseURL: testutil.NewTestServer(t, mockApiRoot).URL,
+	}
+	custom := &customSource{execPath: "/opt/terraform"}
+
+	i := install.NewInstaller()
+	i.SetLogger(testutil.TestLogger())
+	i.SetPolicy(&policy.VersionRange{
+		Product: "terraform",
+		Min:     version.Must(version.NewVersion("1.0.0")),
+	})
+
+	ctx := context.Background()
+	result, err := i.EnsureWithResult(ctx, []src.Source{releasesSource, custom})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { i.Remove(ctx) })
+
+	if result.Source != custom {
+		t.Fatalf("unexpected source: %#v", result.Source)
+	}
+	if len(result.SkippedSources) != 1 || !policy.IsDenied(result.SkippedSources[0].Err) {
+		t.Fatalf("expected releases source to be denied, given: %#v", result.SkippedSources)
+	}
+}
+
 func TestInstaller_Install(t *testing.T) {
 	testutil.EndToEndTest(t)
 
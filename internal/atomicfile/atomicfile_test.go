@@ -0,0 +1,50 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package atomicfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "entry.json")
+
+	for _, data := range []string{"first", "second"} {
+		err := Write(path, []byte(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != data {
+			t.Fatalf("expected %q, got %q", data, string(b))
+		}
+	}
+
+	// no temporary files are left behind
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected only %q, found %d files", path, len(entries))
+	}
+}
+
+func TestWrite_missingDir(t *testing.T) {
+	t.Parallel()
+
+	err := Write(filepath.Join(t.TempDir(), "missing", "entry.json"), []byte("data"))
+	if err == nil {
+		t.Fatal("expected error when directory doesn't exist")
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package release contains helpers shared by sources
+// which install products from the releases site
+package release
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/hashicorp/go-version"
+	rjson "github.com/hashicorp/hc-install/releasesjson"
+	"github.com/hashicorp/hc-install/src"
+)
+
+// NewArtifact describes the unpacked archive of the given version
+func NewArtifact(v *version.Version, up *rjson.UnpackedProduct, execPath string) *src.Artifact {
+	return &src.Artifact{
+		Version:               v,
+		SHA256:                up.ArchiveChecksum.String(),
+		SigningKeyID:          up.SigningKeyID,
+		LicensePaths:          up.LicensePaths,
+		SigningKeyFingerprint: up.SigningKeyFingerprint,
+		ExtraFiles:            extraFiles(up, execPath),
+	}
+}
+
+// extraFiles returns unpacked files other than the binary and licenses
+func extraFiles(up *rjson.UnpackedProduct, execPath string) []string {
+	var paths []string
+	for _, path := range up.UnpackedPaths {
+		if path == execPath || slices.Contains(up.LicensePaths, path) {
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// Resolution describes the archive of the product version
+// which the downloader would download
+func Resolution(d *rjson.Downloader, pv *rjson.ProductVersion, installDir, binaryName string) (*src.Resolution, error) {
+	pb, err := d.FindBuild(pv)
+	if err != nil {
+		return nil, err
+	}
+	archiveURL, err := d.ArchiveURL(pb)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &src.Resolution{
+		Version:    pv.Version,
+		ArchiveURL: archiveURL,
+	}
+	if installDir != "" {
+		r.ExecPath = filepath.Join(installDir, binaryName)
+	}
+	return r, nil
+}
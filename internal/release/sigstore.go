@@ -0,0 +1,49 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package release
+
+import (
+	"fmt"
+	"log"
+
+	rjson "github.com/hashicorp/hc-install/releasesjson"
+	"github.com/hashicorp/hc-install/src"
+)
+
+func ValidateSigstoreOptions(so *src.SigstoreOptions) error {
+	if so == nil {
+		return nil
+	}
+
+	if len(so.TrustedRoot) == 0 {
+		return fmt.Errorf("TrustedRoot must be provided for Sigstore verification")
+	}
+	if so.CertificateIdentity == "" || so.CertificateOIDCIssuer == "" {
+		return fmt.Errorf("CertificateIdentity and CertificateOIDCIssuer must be provided for Sigstore verification")
+	}
+
+	return nil
+}
+
+// ChecksumVerifiers returns verifiers of checksums, or nil
+// to use the default verification of the PGP signature
+func ChecksumVerifiers(so *src.SigstoreOptions, armoredPublicKey string, additionalKeys []string, logger *log.Logger) []rjson.ChecksumVerifier {
+	if so == nil {
+		return nil
+	}
+
+	return []rjson.ChecksumVerifier{
+		&rjson.PGPVerifier{
+			ArmoredPublicKeys: append([]string{armoredPublicKey}, additionalKeys...),
+			Logger:            logger,
+		},
+		&rjson.SigstoreVerifier{
+			TrustedRoot:           so.TrustedRoot,
+			CertificateIdentity:   so.CertificateIdentity,
+			CertificateOIDCIssuer: so.CertificateOIDCIssuer,
+			PredicateType:         so.PredicateType,
+			Logger:                logger,
+		},
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package tempdir creates temporary directories marked as owned
+// by hc-install, such that they can be found and garbage collected
+// if the caller never removes them (e.g. after a crash).
+package tempdir
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// errLocked is returned by lockFile if another process
+// (or another open file) holds the lock
+var errLocked = errors.New("locked")
+
+// MarkerFileName is the name of the file placed in temporary
+// directories created by hc-install, identifying their owner
+const MarkerFileName = ".hc-install-owner.json"
+
+// Marker identifies the process which created a temporary directory
+type Marker struct {
+	PID       int       `json:"pid"`
+	Hostname  string    `json:"hostname"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+var (
+	// lockedMarkers holds marker files of directories created
+	// by the current process, which are locked until removal
+	lockedMarkers   = make(map[string]*os.File)
+	lockedMarkersMu sync.Mutex
+)
+
+// MkdirTemp creates a new temporary directory in the default
+// directory for temporary files (see os.MkdirTemp)
+// and marks it as owned by the current process, which holds
+// a lock on the marker until the directory is removed
+// via RemoveAll or the process exits
+func MkdirTemp(pattern string) (string, error) {
+	dir, err := os.MkdirTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, MarkerFileName), os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	err = lockFile(f)
+	if err != nil {
+		f.Close()
+		os.RemoveAll(dir)
+		return "", err
+	}
+
+	hostname, _ := os.Hostname()
+	m := &Marker{
+		PID:       os.Getpid(),
+		Hostname:  hostname,
+		CreatedAt: time.Now().UTC(),
+	}
+	err = json.NewEncoder(f).Encode(m)
+	if err != nil {
+		f.Close()
+		os.RemoveAll(dir)
+		return "", err
+	}
+
+	lockedMarkersMu.Lock()
+	lockedMarkers[dir] = f
+	lockedMarkersMu.Unlock()
+
+	return dir, nil
+}
+
+// RemoveAll releases the lock on the directory if it was created
+// by the current process and removes it (see os.RemoveAll)
+func RemoveAll(path string) error {
+	lockedMarkersMu.Lock()
+	f, ok := lockedMarkers[path]
+	delete(lockedMarkers, path)
+	lockedMarkersMu.Unlock()
+
+	if ok {
+		// the marker can't be removed while open on Windows
+		f.Close()
+	}
+	return os.RemoveAll(path)
+}
+
+// ReadMarker reads the marker of the directory. Errors satisfy
+// errors.Is(err, fs.ErrNotExist) if the directory is not marked.
+func ReadMarker(dir string) (*Marker, error) {
+	b, err := os.ReadFile(filepath.Join(dir, MarkerFileName))
+	if err != nil {
+		return nil, err
+	}
+	m := &Marker{}
+	err = json.Unmarshal(b, m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Locked reports whether the marker of the directory is locked,
+// i.e. whether any process (possibly on another host sharing
+// the directory) is still using it. Unlike checking whether the owning
+// PID is running, this isn't fooled by PIDs reused by other processes.
+func Locked(dir string) (bool, error) {
+	f, err := os.OpenFile(filepath.Join(dir, MarkerFileName), os.O_RDWR, 0)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	err = lockFile(f)
+	if errors.Is(err, errLocked) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return false, nil
+}
@@ -0,0 +1,45 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package testutil
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/product"
+)
+
+// CreateFakeBinaries creates an executable file with the given name
+// in a separate directory for each version, containing the version
+func CreateFakeBinaries(t *testing.T, name string, versions ...string) []string {
+	dirs := make([]string, 0, len(versions))
+	for _, v := range versions {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, name), []byte(v), 0o700)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// FakeProduct reads the version from the content of the binary
+// (see CreateFakeBinaries)
+func FakeProduct(name string) product.Product {
+	return product.Product{
+		Name:       name,
+		BinaryName: func() string { return name },
+		GetVersion: func(_ context.Context, path string) (*version.Version, error) {
+			b, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			return version.NewVersion(string(b))
+		},
+	}
+}
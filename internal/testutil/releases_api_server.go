@@ -0,0 +1,137 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// NewReleasesAPITestServer returns a server mocking the paginated
+// releases API (v1), serving releases of each product
+// from <mockDir>/<product>.json (a JSON array of releases)
+func NewReleasesAPITestServer(t testing.TB, mockDir string) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/releases/{product}", func(w http.ResponseWriter, req *http.Request) {
+		releases, err := loadReleases(mockDir, req.PathValue("product"))
+		if err != nil {
+			writeJSONError(w, http.StatusNotFound, err)
+			return
+		}
+
+		limit := 10
+		if rawLimit := req.URL.Query().Get("limit"); rawLimit != "" {
+			limit, err = strconv.Atoi(rawLimit)
+			if err != nil || limit < 1 || limit > 20 {
+				writeJSONError(w, http.StatusBadRequest, fmt.Errorf("invalid limit: %q", rawLimit))
+				return
+			}
+		}
+
+		var after time.Time
+		if rawAfter := req.URL.Query().Get("after"); rawAfter != "" {
+			after, err = time.Parse(time.RFC3339Nano, rawAfter)
+			if err != nil {
+				writeJSONError(w, http.StatusBadRequest, fmt.Errorf("invalid after: %q", rawAfter))
+				return
+			}
+		}
+
+		page := make([]json.RawMessage, 0)
+		for _, r := range releases {
+			if !after.IsZero() && !r.created.Before(after) {
+				continue
+			}
+			if len(page) == limit {
+				break
+			}
+			page = append(page, r.raw)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(page)
+	})
+	mux.HandleFunc("GET /v1/releases/{product}/{version}", func(w http.ResponseWriter, req *http.Request) {
+		releases, err := loadReleases(mockDir, req.PathValue("product"))
+		if err != nil {
+			writeJSONError(w, http.StatusNotFound, err)
+			return
+		}
+
+		for _, r := range releases {
+			if r.version == req.PathValue("version") {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write(r.raw)
+				return
+			}
+		}
+		writeJSONError(w, http.StatusNotFound, fmt.Errorf("version not found"))
+	})
+
+	ts := httptest.NewServer(mux)
+
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+type mockRelease struct {
+	version string
+	created time.Time
+	raw     json.RawMessage
+}
+
+// loadReleases returns releases of the product sorted
+// from the newest, as returned by the API
+func loadReleases(mockDir, productName string) ([]*mockRelease, error) {
+	b, err := os.ReadFile(filepath.Join(mockDir, filepath.Base(productName)+".json"))
+	if err != nil {
+		return nil, err
+	}
+
+	rawReleases := make([]json.RawMessage, 0)
+	err = json.Unmarshal(b, &rawReleases)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]*mockRelease, 0, len(rawReleases))
+	for _, raw := range rawReleases {
+		var r struct {
+			Version          string    `json:"version"`
+			TimestampCreated time.Time `json:"timestamp_created"`
+		}
+		err = json.Unmarshal(raw, &r)
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, &mockRelease{
+			version: r.Version,
+			created: r.TimestampCreated,
+			raw:     raw,
+		})
+	}
+
+	sort.SliceStable(releases, func(i, j int) bool {
+		return releases[i].created.After(releases[j].created)
+	})
+
+	return releases, nil
+}
+
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `{"code": %d, "message": %q}`, status, err)
+}
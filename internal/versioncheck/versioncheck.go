@@ -0,0 +1,92 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package versioncheck obtains versions of binaries, e.g. to verify
+// that installed binaries report the version which was requested.
+package versioncheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-version"
+	hcerrors "github.com/hashicorp/hc-install/errors"
+	"github.com/hashicorp/hc-install/product"
+	"github.com/hashicorp/hc-install/registry"
+)
+
+// Validate returns an error if the version of the product
+// can't be obtained via Detect (e.g. for the purposes of Check)
+func Validate(p product.Product, preferReadVersion bool) error {
+	if p.GetVersion == nil && (!preferReadVersion || p.ReadVersion == nil) {
+		return fmt.Errorf("undeclared version getter")
+	}
+	return nil
+}
+
+// Check obtains the version of the binary at execPath via
+// Product.GetVersion (or Product.ReadVersion, if preferReadVersion
+// is set) and returns *hcerrors.VersionMismatchError if it differs
+// from the expected version, including any metadata (e.g. +ent)
+func Check(ctx context.Context, logger *log.Logger, p product.Product, preferReadVersion bool,
+	execPath string, expected *version.Version) error {
+	actual, err := Detect(ctx, logger, p, preferReadVersion, execPath)
+	if err != nil {
+		return fmt.Errorf("unable to verify version of %s: %w", execPath, err)
+	}
+
+	if !actual.Equal(expected) || actual.Metadata() != expected.Metadata() {
+		return &hcerrors.VersionMismatchError{
+			ExecPath: execPath,
+			Expected: expected,
+			Actual:   actual,
+		}
+	}
+	logger.Printf("verified that %s reports version %s", execPath, actual)
+
+	return nil
+}
+
+// RollBack removes items created by an install which failed Check,
+// restoring any files they overwrote, such that the binary reporting
+// a different version isn't left behind. It returns items which are
+// left to be removed, i.e. all of them unless err is a version
+// mismatch and the rollback succeeded.
+func RollBack(err error, items []*registry.Item, logger *log.Logger) []*registry.Item {
+	var mismatchErr *hcerrors.VersionMismatchError
+	if !errors.As(err, &mismatchErr) {
+		return items
+	}
+
+	rbErr := registry.RemoveItems(items, logger)
+	if rbErr != nil {
+		logger.Printf("failed to roll back install of %s: %s", mismatchErr.ExecPath, rbErr)
+		return items
+	}
+	logger.Printf("rolled back install of %s", mismatchErr.ExecPath)
+	return nil
+}
+
+// Detect obtains the version of the binary at execPath, preferring
+// Product.ReadVersion (if preferReadVersion is set and it's declared)
+// and falling back to Product.GetVersion, which executes the binary
+func Detect(ctx context.Context, logger *log.Logger, p product.Product, preferReadVersion bool,
+	execPath string) (*version.Version, error) {
+	if preferReadVersion && p.ReadVersion != nil {
+		v, err := p.ReadVersion(ctx, execPath)
+		if err == nil {
+			return v, nil
+		}
+		if p.GetVersion == nil {
+			return nil, err
+		}
+		logger.Printf("unable to read version of %s, executing it instead: %s", execPath, err)
+	}
+
+	if p.GetVersion == nil {
+		return nil, fmt.Errorf("undeclared version getter")
+	}
+	return p.GetVersion(ctx, execPath)
+}
@@ -0,0 +1,252 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package outdated reports installed product versions
+// along with the latest versions available for them.
+package outdated
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/errors"
+	"github.com/hashicorp/hc-install/fs"
+	"github.com/hashicorp/hc-install/policy"
+	"github.com/hashicorp/hc-install/product"
+	rjson "github.com/hashicorp/hc-install/releasesjson"
+)
+
+var discardLogger = log.New(io.Discard, "", 0)
+
+// Scope determines which newer versions are considered updates
+type Scope string
+
+const (
+	// ScopeLatest considers any newer version (default)
+	ScopeLatest Scope = "latest"
+
+	// ScopeMinor considers newer versions with the same major version
+	ScopeMinor Scope = "minor"
+
+	// ScopePatch considers newer versions with the same major
+	// and minor version
+	ScopePatch Scope = "patch"
+)
+
+// DefaultProducts represents products checked by default
+var DefaultProducts = []product.Product{
+	product.Consul,
+	product.Nomad,
+	product.Packer,
+	product.Terraform,
+	product.Vault,
+}
+
+// Checker finds installed binaries of products within $PATH
+// (and optionally in directories of version managers) and compares
+// their versions with versions available on the releases site
+type Checker struct {
+	// Products represents products to check,
+	// defaulting to DefaultProducts
+	Products []product.Product
+
+	// Scope determines which newer versions are considered updates
+	Scope Scope
+
+	// ExtraPaths, DiscoverManagedInstalls, PreferBuildInfo,
+	// TrustReceipts and VersionCache configure how installed binaries
+	// are found and their versions detected (see fs.Version)
+	ExtraPaths              []string
+	DiscoverManagedInstalls bool
+	PreferBuildInfo         bool
+	TrustReceipts           bool
+	VersionCache            *fs.VersionCache
+
+	// FindTimeout represents the timeout for finding
+	// the binaries of each product
+	FindTimeout time.Duration
+
+	// ApiBaseURL is an optional field that specifies a custom URL
+	// of the releases site to list versions from
+	ApiBaseURL string
+
+	// HTTPClient represents an optional client used for all requests
+	HTTPClient *http.Client
+
+	// IndexCache represents an optional on-disk cache of index.json files
+	IndexCache *rjson.IndexCache
+
+	// Policy represents an optional policy which has to allow
+	// a version for it to be considered an update
+	Policy policy.Policy
+
+	logger *log.Logger
+}
+
+// Report describes an installed binary and available newer versions
+type Report struct {
+	Product          string
+	ExecPath         string
+	InstalledVersion *version.Version
+
+	// LatestVersion is the latest available version
+	// (of the same edition, e.g. enterprise), nil if unknown
+	LatestVersion *version.Version
+
+	// UpdateVersion is the latest available version within Scope
+	// which is newer than InstalledVersion, nil if up to date
+	UpdateVersion *version.Version
+}
+
+// Outdated returns true if a newer version is available within Scope
+func (r *Report) Outdated() bool {
+	return r.UpdateVersion != nil
+}
+
+func (c *Checker) SetLogger(logger *log.Logger) {
+	c.logger = logger
+}
+
+func (c *Checker) log() *log.Logger {
+	if c.logger == nil {
+		return discardLogger
+	}
+	return c.logger
+}
+
+func (c *Checker) Validate() error {
+	switch c.Scope {
+	case "", ScopeLatest, ScopeMinor, ScopePatch:
+	default:
+		return fmt.Errorf("unknown scope: %q", c.Scope)
+	}
+	return nil
+}
+
+// Check returns reports of all found binaries of products,
+// in order of products and lookup directories. Products which
+// are not installed are skipped.
+func (c *Checker) Check(ctx context.Context) ([]*Report, error) {
+	err := c.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	products := c.Products
+	if len(products) == 0 {
+		products = DefaultProducts
+	}
+
+	reports := make([]*Report, 0)
+	for _, p := range products {
+		candidates, err := c.find(ctx, p)
+		if err != nil {
+			if errors.IsErrorSkippable(err) {
+				c.log().Printf("skipping %s: %s", p.Name, err)
+				continue
+			}
+			return nil, err
+		}
+
+		versions, err := c.listVersions(ctx, p)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p.Name, err)
+		}
+
+		for _, candidate := range candidates {
+			reports = append(reports, c.report(p, candidate, versions))
+		}
+	}
+
+	return reports, nil
+}
+
+func (c *Checker) find(ctx context.Context, p product.Product) ([]*fs.Candidate, error) {
+	fv := &fs.Version{
+		Product:                 p,
+		Constraints:             version.MustConstraints(version.NewConstraint(">= 0.0.0")),
+		ExtraPaths:              c.ExtraPaths,
+		Timeout:                 c.FindTimeout,
+		DiscoverManagedInstalls: c.DiscoverManagedInstalls,
+		PreferBuildInfo:         c.PreferBuildInfo,
+		TrustReceipts:           c.TrustReceipts,
+		VersionCache:            c.VersionCache,
+	}
+	fv.SetLogger(c.log())
+	return fv.FindAll(ctx)
+}
+
+// listVersions returns versions available on the releases site,
+// excluding prereleases and versions denied by the policy
+func (c *Checker) listVersions(ctx context.Context, p product.Product) ([]*version.Version, error) {
+	rels := rjson.NewReleases()
+	if c.ApiBaseURL != "" {
+		rels.BaseURL = c.ApiBaseURL
+	}
+	rels.Cache = c.IndexCache
+	rels.HTTPClient = c.HTTPClient
+	rels.SetLogger(c.log())
+	pvs, err := rels.ListProductVersions(ctx, p.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]*version.Version, 0, len(pvs))
+	for _, pv := range pvs {
+		if pv.Version.Prerelease() != "" {
+			continue
+		}
+		err := policy.Check(ctx, c.Policy, &policy.Candidate{
+			Product:           p.Name,
+			Version:           pv.Version,
+			SignatureVerified: true,
+		})
+		if err != nil {
+			c.log().Printf("skipping version %s: %s", pv.Version, err)
+			continue
+		}
+		versions = append(versions, pv.Version)
+	}
+	return versions, nil
+}
+
+func (c *Checker) report(p product.Product, candidate *fs.Candidate, versions []*version.Version) *Report {
+	installed := candidate.Version
+	r := &Report{
+		Product:          p.Name,
+		ExecPath:         candidate.ExecPath,
+		InstalledVersion: installed,
+	}
+
+	for _, v := range versions {
+		if v.Metadata() != installed.Metadata() {
+			// compare enterprise versions with enterprise versions only
+			continue
+		}
+		if r.LatestVersion == nil || v.GreaterThan(r.LatestVersion) {
+			r.LatestVersion = v
+		}
+		if v.GreaterThan(installed) && c.inScope(installed, v) &&
+			(r.UpdateVersion == nil || v.GreaterThan(r.UpdateVersion)) {
+			r.UpdateVersion = v
+		}
+	}
+
+	return r
+}
+
+func (c *Checker) inScope(installed, v *version.Version) bool {
+	is, vs := installed.Segments(), v.Segments()
+	switch c.Scope {
+	case ScopePatch:
+		return is[0] == vs[0] && is[1] == vs[1]
+	case ScopeMinor:
+		return is[0] == vs[0]
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !windows
+// +build !windows
+
+package outdated
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/internal/testutil"
+	"github.com/hashicorp/hc-install/policy"
+	"github.com/hashicorp/hc-install/product"
+)
+
+func TestChecker_Check(t *testing.T) {
+	t.Setenv("PATH", "")
+
+	srv := newReleasesServer(t, "fake",
+		"1.4.0", "1.4.7", "1.5.0", "1.5.3", "1.5.3+ent", "2.0.0", "2.1.0-rc1")
+	dirs := testutil.CreateFakeBinaries(t, "fake", "1.4.0", "1.5.3+ent", "2.0.0")
+
+	testCases := map[string]struct {
+		scope          Scope
+		policy         policy.Policy
+		expectedReport string
+	}{
+		"latest": {
+			scope: ScopeLatest,
+			expectedReport: `1.4.0 latest=2.0.0 update=2.0.0
+1.5.3+ent latest=1.5.3+ent update=
+2.0.0 latest=2.0.0 update=`,
+		},
+		"minor": {
+			scope: ScopeMinor,
+			expectedReport: `1.4.0 latest=2.0.0 update=1.5.3
+1.5.3+ent latest=1.5.3+ent update=
+2.0.0 latest=2.0.0 update=`,
+		},
+		"patch": {
+			scope: ScopePatch,
+			expectedReport: `1.4.0 latest=2.0.0 update=1.4.7
+1.5.3+ent latest=1.5.3+ent update=
+2.0.0 latest=2.0.0 update=`,
+		},
+		"policy": {
+			scope: ScopeMinor,
+			policy: &policy.DenyVersions{
+				Constraints: []version.Constraints{
+					version.MustConstraints(version.NewConstraint(">= 1.5.0, < 1.6.0")),
+				},
+			},
+			expectedReport: `1.4.0 latest=2.0.0 update=1.4.7
+1.5.3+ent latest= update=
+2.0.0 latest=2.0.0 update=`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := &Checker{
+				Products:   []product.Product{testutil.FakeProduct("fake"), testutil.FakeProduct("missing")},
+				Scope:      testCase.scope,
+				ExtraPaths: dirs,
+				ApiBaseURL: srv.URL,
+				Policy:     testCase.policy,
+			}
+			c.SetLogger(testutil.TestLogger())
+
+			reports, err := c.Check(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			lines := make([]string, 0, len(reports))
+			for i, r := range reports {
+				if r.ExecPath != filepath.Join(dirs[i], "fake") {
+					t.Fatalf("unexpected path: %q", r.ExecPath)
+				}
+				lines = append(lines, fmt.Sprintf("%s latest=%s update=%s",
+					r.InstalledVersion, versionString(r.LatestVersion), versionString(r.UpdateVersion)))
+			}
+			report := strings.Join(lines, "\n")
+			if report != testCase.expectedReport {
+				t.Fatalf("unexpected report:\n%s\nexpected:\n%s", report, testCase.expectedReport)
+			}
+		})
+	}
+}
+
+func TestChecker_Validate(t *testing.T) {
+	t.Parallel()
+
+	c := &Checker{Scope: "major"}
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected unknown scope to be invalid")
+	}
+}
+
+func versionString(v *version.Version) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
+
+func newReleasesServer(t *testing.T, productName string, versions ...string) *httptest.Server {
+	entries := make([]string, 0, len(versions))
+	for _, v := range versions {
+		entries = append(entries, fmt.Sprintf(`%q: {"name": %q, "version": %q}`, v, productName, v))
+	}
+	index := fmt.Sprintf(`{"name": %q, "versions": {%s}}`, productName, strings.Join(entries, ","))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/"+productName+"/index.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(index))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
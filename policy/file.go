@@ -0,0 +1,128 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/go-version"
+)
+
+// file represents the policy file format, e.g.
+//
+//	{
+//	  "rules": [
+//	    {"product": "terraform", "deny_versions": [">= 1.2.0, < 1.2.3"], "reason": "CVE-2022-0000"},
+//	    {"product": "terraform", "min_version": "1.0.0"},
+//	    {"product": "vault", "require_enterprise": true},
+//	    {"deny_prereleases": true, "require_signature": true}
+//	  ]
+//	}
+type file struct {
+	Rules []*fileRule `json:"rules"`
+}
+
+// fileRule represents one or more rules scoped to the same product
+// (or any product if empty)
+type fileRule struct {
+	Product           string   `json:"product"`
+	DenyVersions      []string `json:"deny_versions"`
+	Reason            string   `json:"reason"`
+	MinVersion        string   `json:"min_version"`
+	MaxVersion        string   `json:"max_version"`
+	RequireEnterprise bool     `json:"require_enterprise"`
+	DenyPrereleases   bool     `json:"deny_prereleases"`
+	RequireSignature  bool     `json:"require_signature"`
+}
+
+// LoadFile reads the policy from a JSON file
+func LoadFile(path string) (Policies, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := Parse(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy file %q: %w", path, err)
+	}
+	return p, nil
+}
+
+// Parse parses the policy from JSON (see LoadFile)
+func Parse(b []byte) (Policies, error) {
+	f := &file{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.DisallowUnknownFields()
+	err := d.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make(Policies, 0)
+	for i, r := range f.Rules {
+		rules, err := r.policies()
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		if len(rules) == 0 {
+			return nil, fmt.Errorf("rule %d: no rules declared", i)
+		}
+		policies = append(policies, rules...)
+	}
+
+	return policies, nil
+}
+
+func (r *fileRule) policies() (Policies, error) {
+	policies := make(Policies, 0)
+
+	if len(r.DenyVersions) > 0 {
+		dv := &DenyVersions{
+			Product: r.Product,
+			Reason:  r.Reason,
+		}
+		for _, rawConstraint := range r.DenyVersions {
+			vc, err := version.NewConstraint(rawConstraint)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version constraint %q: %w", rawConstraint, err)
+			}
+			dv.Constraints = append(dv.Constraints, vc)
+		}
+		policies = append(policies, dv)
+	}
+
+	if r.MinVersion != "" || r.MaxVersion != "" {
+		vr := &VersionRange{Product: r.Product}
+		var err error
+		if r.MinVersion != "" {
+			vr.Min, err = version.NewVersion(r.MinVersion)
+			if err != nil {
+				return nil, fmt.Errorf("invalid min_version: %w", err)
+			}
+		}
+		if r.MaxVersion != "" {
+			vr.Max, err = version.NewVersion(r.MaxVersion)
+			if err != nil {
+				return nil, fmt.Errorf("invalid max_version: %w", err)
+			}
+		}
+		policies = append(policies, vr)
+	}
+
+	if r.RequireEnterprise {
+		policies = append(policies, &RequireEnterprise{Product: r.Product})
+	}
+	if r.DenyPrereleases {
+		policies = append(policies, &DenyPrereleases{Product: r.Product})
+	}
+	if r.RequireSignature {
+		policies = append(policies, &RequireSignature{Product: r.Product})
+	}
+
+	return policies, nil
+}
@@ -0,0 +1,82 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package policy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	err := os.WriteFile(path, []byte(`{
+	"rules": [
+		{"product": "terraform", "deny_versions": [">= 1.2.0, < 1.2.3"], "reason": "CVE-2022-0000"},
+		{"product": "terraform", "min_version": "1.0.0"},
+		{"product": "vault", "require_enterprise": true},
+		{"deny_prereleases": true, "require_signature": true}
+	]
+}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p) != 5 {
+		t.Fatalf("expected 5 rules, %d parsed", len(p))
+	}
+
+	ctx := context.Background()
+	allowed := []*Candidate{
+		testCandidate("terraform", "1.2.3", true),
+		testCandidate("vault", "1.15.0+ent", true),
+	}
+	for _, c := range allowed {
+		if err := p.Check(ctx, c); err != nil {
+			t.Fatalf("expected %s to be allowed, got error: %s", c, err)
+		}
+	}
+
+	denied := []*Candidate{
+		testCandidate("terraform", "1.2.2", true),
+		testCandidate("terraform", "0.15.5", true),
+		testCandidate("vault", "1.15.0", true),
+		testCandidate("consul", "1.17.0-rc1", true),
+		testCandidate("consul", "1.17.0", false),
+	}
+	for _, c := range denied {
+		if err := p.Check(ctx, c); !IsDenied(err) {
+			t.Fatalf("expected %s to be denied, got: %v", c, err)
+		}
+	}
+}
+
+func TestParse_invalid(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"unknown-field":      `{"rules": [{"deny": ["1.0.0"]}]}`,
+		"invalid-constraint": `{"rules": [{"deny_versions": ["not-a-version"]}]}`,
+		"invalid-min":        `{"rules": [{"min_version": "x"}]}`,
+		"empty-rule":         `{"rules": [{"product": "terraform"}]}`,
+	}
+
+	for name, raw := range testCases {
+		name, raw := name, raw
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse([]byte(raw))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package policy allows restricting which product versions may be
+// installed or used, e.g. to block versions with known vulnerabilities.
+//
+// Sources implementing Settable consult the policy during
+// version selection and before downloading anything, such that
+// denied versions are skipped or never downloaded.
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-version"
+)
+
+// Policy decides whether a candidate may be installed or used
+type Policy interface {
+	// Check returns nil if the candidate is allowed, or an error
+	// (typically *DeniedError) describing why it was denied
+	Check(ctx context.Context, c *Candidate) error
+}
+
+// Settable is implemented by sources which consult a policy
+// when selecting a version, such that denied versions are skipped
+// or not downloaded
+type Settable interface {
+	SetPolicy(p Policy)
+}
+
+// Candidate represents a product version considered by a source
+type Candidate struct {
+	// Product is the name of the product, e.g. terraform
+	Product string
+
+	// Version is the version of the product, including any
+	// metadata, e.g. 1.15.0+ent for enterprise versions
+	Version *version.Version
+
+	// SignatureVerified indicates whether checksums of the candidate
+	// are (or would be) verified using the signature. It is false for
+	// binaries found on disk or when checksum verification is skipped.
+	SignatureVerified bool
+}
+
+func (c *Candidate) String() string {
+	return fmt.Sprintf("%s@%s", c.Product, c.Version)
+}
+
+// DeniedError is returned when a candidate is denied by a policy
+type DeniedError struct {
+	Candidate *Candidate
+	Reason    string
+}
+
+func (e *DeniedError) Error() string {
+	return fmt.Sprintf("%s denied by policy: %s", e.Candidate, e.Reason)
+}
+
+// IsDenied returns true if the error (or any wrapped error)
+// represents a denial by a policy
+func IsDenied(err error) bool {
+	var de *DeniedError
+	return errors.As(err, &de)
+}
+
+func denied(c *Candidate, format string, a ...interface{}) error {
+	return &DeniedError{
+		Candidate: c,
+		Reason:    fmt.Sprintf(format, a...),
+	}
+}
+
+// Policies combines multiple policies, all of which have to allow
+// a candidate. Nil policies are ignored.
+type Policies []Policy
+
+func (ps Policies) Check(ctx context.Context, c *Candidate) error {
+	var errs *multierror.Error
+	for _, p := range ps {
+		if p == nil {
+			continue
+		}
+		err := p.Check(ctx, c)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// Check checks the candidate against the policy,
+// allowing any candidate if the policy is nil
+func Check(ctx context.Context, p Policy, c *Candidate) error {
+	if p == nil {
+		return nil
+	}
+	return p.Check(ctx, c)
+}
@@ -0,0 +1,141 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+)
+
+func TestPolicies_Check(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		policy      Policy
+		candidate   *Candidate
+		expectedErr bool
+	}{
+		"deny-versions-match": {
+			policy: &DenyVersions{
+				Constraints: []version.Constraints{
+					version.MustConstraints(version.NewConstraint(">= 1.2.0, < 1.2.3")),
+				},
+				Reason: "CVE-2022-0000",
+			},
+			candidate:   testCandidate("terraform", "1.2.1", true),
+			expectedErr: true,
+		},
+		"deny-versions-no-match": {
+			policy: &DenyVersions{
+				Constraints: []version.Constraints{
+					version.MustConstraints(version.NewConstraint(">= 1.2.0, < 1.2.3")),
+				},
+			},
+			candidate: testCandidate("terraform", "1.2.3", true),
+		},
+		"deny-versions-other-product": {
+			policy: &DenyVersions{
+				Product: "vault",
+				Constraints: []version.Constraints{
+					version.MustConstraints(version.NewConstraint("1.2.1")),
+				},
+			},
+			candidate: testCandidate("terraform", "1.2.1", true),
+		},
+		"version-range-below-min": {
+			policy: &VersionRange{
+				Min: version.Must(version.NewVersion("1.0.0")),
+			},
+			candidate:   testCandidate("terraform", "0.15.5", true),
+			expectedErr: true,
+		},
+		"version-range-above-max": {
+			policy: &VersionRange{
+				Max: version.Must(version.NewVersion("1.5.0")),
+			},
+			candidate:   testCandidate("terraform", "1.5.1", true),
+			expectedErr: true,
+		},
+		"version-range-within-ent": {
+			policy: &VersionRange{
+				Min: version.Must(version.NewVersion("1.0.0")),
+				Max: version.Must(version.NewVersion("1.15.0")),
+			},
+			candidate: testCandidate("vault", "1.15.0+ent", true),
+		},
+		"require-enterprise-oss": {
+			policy:      &RequireEnterprise{Product: "vault"},
+			candidate:   testCandidate("vault", "1.15.0", true),
+			expectedErr: true,
+		},
+		"require-enterprise-ent-fips": {
+			policy:    &RequireEnterprise{Product: "vault"},
+			candidate: testCandidate("vault", "1.15.0+ent.fips1402", true),
+		},
+		"deny-prereleases": {
+			policy:      &DenyPrereleases{},
+			candidate:   testCandidate("terraform", "1.6.0-rc1", true),
+			expectedErr: true,
+		},
+		"require-signature": {
+			policy:      &RequireSignature{},
+			candidate:   testCandidate("terraform", "1.6.0", false),
+			expectedErr: true,
+		},
+		"policies-nil": {
+			policy:    Policies{nil},
+			candidate: testCandidate("terraform", "1.6.0", false),
+		},
+		"policies-any-denies": {
+			policy: Policies{
+				&DenyPrereleases{},
+				&RequireSignature{},
+			},
+			candidate:   testCandidate("terraform", "1.6.0", false),
+			expectedErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.policy.Check(context.Background(), testCase.candidate)
+			if err == nil && testCase.expectedErr {
+				t.Fatal("expected candidate to be denied")
+			}
+			if err != nil && !testCase.expectedErr {
+				t.Fatalf("expected candidate to be allowed, got error: %s", err)
+			}
+			if err != nil && !IsDenied(err) {
+				t.Fatalf("expected denial, got error: %s", err)
+			}
+		})
+	}
+}
+
+func TestIsDenied_wrapped(t *testing.T) {
+	t.Parallel()
+
+	err := Policies{&DenyPrereleases{}}.Check(context.Background(),
+		testCandidate("terraform", "1.6.0-rc1", true))
+	err = fmt.Errorf("no matching version: %w", err)
+
+	if !IsDenied(err) {
+		t.Fatalf("expected wrapped denial, got error: %s", err)
+	}
+}
+
+func testCandidate(product, rawVersion string, signatureVerified bool) *Candidate {
+	return &Candidate{
+		Product:           product,
+		Version:           version.Must(version.NewVersion(rawVersion)),
+		SignatureVerified: signatureVerified,
+	}
+}
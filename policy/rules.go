@@ -0,0 +1,121 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package policy
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// DenyVersions denies versions matching any of the Constraints,
+// e.g. versions affected by a known vulnerability
+type DenyVersions struct {
+	// Product limits the rule to a product (empty means any product)
+	Product string
+
+	Constraints []version.Constraints
+
+	// Reason is reported when a version is denied, e.g. a CVE ID
+	Reason string
+}
+
+func (dv *DenyVersions) Check(ctx context.Context, c *Candidate) error {
+	if !appliesTo(dv.Product, c) {
+		return nil
+	}
+	for _, vc := range dv.Constraints {
+		if vc.Check(c.Version) {
+			reason := dv.Reason
+			if reason == "" {
+				reason = "version is denied"
+			}
+			return denied(c, "%s (%s)", reason, vc)
+		}
+	}
+	return nil
+}
+
+// VersionRange allows only versions between Min and Max (inclusive)
+type VersionRange struct {
+	// Product limits the rule to a product (empty means any product)
+	Product string
+
+	// Min and Max represent the bounds, either of which may be nil
+	Min *version.Version
+	Max *version.Version
+}
+
+func (vr *VersionRange) Check(ctx context.Context, c *Candidate) error {
+	if !appliesTo(vr.Product, c) {
+		return nil
+	}
+	// metadata (e.g. +ent) is irrelevant for the comparison
+	v := c.Version.Core()
+	if vr.Min != nil && v.LessThan(vr.Min.Core()) {
+		return denied(c, "version is lower than minimum %s", vr.Min)
+	}
+	if vr.Max != nil && v.GreaterThan(vr.Max.Core()) {
+		return denied(c, "version is higher than maximum %s", vr.Max)
+	}
+	return nil
+}
+
+// RequireEnterprise allows only enterprise versions
+type RequireEnterprise struct {
+	// Product limits the rule to a product (empty means any product)
+	Product string
+}
+
+func (re *RequireEnterprise) Check(ctx context.Context, c *Candidate) error {
+	if !appliesTo(re.Product, c) {
+		return nil
+	}
+	metadata := c.Version.Metadata()
+	if metadata != "ent" && !strings.HasPrefix(metadata, "ent.") {
+		return denied(c, "enterprise version is required")
+	}
+	return nil
+}
+
+// DenyPrereleases denies prerelease versions, e.g. 1.5.0-rc1
+type DenyPrereleases struct {
+	// Product limits the rule to a product (empty means any product)
+	Product string
+}
+
+func (dp *DenyPrereleases) Check(ctx context.Context, c *Candidate) error {
+	if !appliesTo(dp.Product, c) {
+		return nil
+	}
+	if c.Version.Prerelease() != "" {
+		return denied(c, "prerelease versions are not allowed")
+	}
+	return nil
+}
+
+// RequireSignature allows only candidates with checksums verified
+// using the signature, which excludes installation with checksum
+// verification skipped. Binaries found on disk were never verified,
+// so it denies all candidates of fs sources, i.e. Ensure always
+// installs or builds the product when this rule applies.
+type RequireSignature struct {
+	// Product limits the rule to a product (empty means any product)
+	Product string
+}
+
+func (rs *RequireSignature) Check(ctx context.Context, c *Candidate) error {
+	if !appliesTo(rs.Product, c) {
+		return nil
+	}
+	if !c.SignatureVerified {
+		return denied(c, "signature verification is required")
+	}
+	return nil
+}
+
+func appliesTo(product string, c *Candidate) bool {
+	return product == "" || product == c.Product
+}
@@ -0,0 +1,153 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package product
+
+import (
+	"context"
+	"debug/buildinfo"
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"golang.org/x/mod/module"
+)
+
+// VersionFromBuildInfo reads the version of a Go binary from its build
+// info (ELF, Mach-O or PE) without executing it, which also works for
+// binaries built for a different platform.
+//
+// The version is read from Version, VersionPrerelease and VersionMetadata
+// variables of versionPkg set via -X linker flags, falling back to the
+// version of the main module if it contains versionPkg.
+func VersionFromBuildInfo(execPath, versionPkg string) (*version.Version, error) {
+	bi, err := buildinfo.ReadFile(execPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read build info of %s: %w", execPath, err)
+	}
+
+	return versionFromBuildInfo(bi, versionPkg)
+}
+
+// buildInfoVersionReader returns a function reading the version
+// from build info, suitable for Product.ReadVersion
+func buildInfoVersionReader(versionPkg string) func(context.Context, string) (*version.Version, error) {
+	return func(_ context.Context, execPath string) (*version.Version, error) {
+		return VersionFromBuildInfo(execPath, versionPkg)
+	}
+}
+
+func versionFromBuildInfo(bi *debug.BuildInfo, versionPkg string) (*version.Version, error) {
+	vars := ldflagsVariables(buildSetting(bi, "-ldflags"))
+
+	if rawVersion, ok := vars[versionPkg+".Version"]; ok && rawVersion != "" {
+		rawVersion = strings.TrimPrefix(rawVersion, "v")
+		if prerelease := vars[versionPkg+".VersionPrerelease"]; prerelease != "" {
+			rawVersion += "-" + prerelease
+		}
+		if metadata := vars[versionPkg+".VersionMetadata"]; metadata != "" {
+			rawVersion += "+" + metadata
+		}
+		v, err := version.NewVersion(rawVersion)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse version %q: %w", rawVersion, err)
+		}
+		return v, nil
+	}
+
+	mainVersion := bi.Main.Version
+	if bi.Main.Path == "" || !strings.HasPrefix(versionPkg, bi.Main.Path+"/") {
+		return nil, fmt.Errorf("unexpected main module %q (expected module containing %s)",
+			bi.Main.Path, versionPkg)
+	}
+	if mainVersion == "" || mainVersion == "(devel)" || module.IsPseudoVersion(mainVersion) {
+		return nil, fmt.Errorf("no version found in build info of %s (main module version: %q)",
+			bi.Main.Path, mainVersion)
+	}
+
+	v, err := version.NewVersion(strings.TrimPrefix(mainVersion, "v"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse version %q: %w", mainVersion, err)
+	}
+	return v, nil
+}
+
+func buildSetting(bi *debug.BuildInfo, key string) string {
+	for _, s := range bi.Settings {
+		if s.Key == key {
+			return s.Value
+		}
+	}
+	return ""
+}
+
+// ldflagsVariables returns variables set via -X in the given linker flags
+func ldflagsVariables(ldflags string) map[string]string {
+	vars := make(map[string]string, 0)
+
+	args := splitQuoted(ldflags)
+	for i := 0; i < len(args); i++ {
+		var definition string
+		switch {
+		case args[i] == "-X" || args[i] == "--X":
+			if i+1 >= len(args) {
+				continue
+			}
+			i++
+			definition = args[i]
+		case strings.HasPrefix(args[i], "-X="):
+			definition = strings.TrimPrefix(args[i], "-X=")
+		case strings.HasPrefix(args[i], "--X="):
+			definition = strings.TrimPrefix(args[i], "--X=")
+		default:
+			continue
+		}
+
+		name, value, ok := strings.Cut(definition, "=")
+		if ok {
+			vars[name] = value
+		}
+	}
+
+	return vars
+}
+
+// splitQuoted splits the string on spaces, respecting
+// single and double quotes, as recorded by the go command
+func splitQuoted(s string) []string {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+	)
+
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+				continue
+			}
+			current.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args
+}
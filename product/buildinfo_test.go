@@ -0,0 +1,146 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package product
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime/debug"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestVersionFromBuildInfo(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		bi              *debug.BuildInfo
+		expectedVersion string
+		expectedErr     bool
+	}{
+		"ldflags": {
+			bi: testBuildInfo("github.com/hashicorp/vault", "(devel)",
+				"-s -w -X github.com/hashicorp/vault/version.Version=1.15.0"),
+			expectedVersion: "1.15.0",
+		},
+		"ldflags-prerelease-metadata": {
+			bi: testBuildInfo("github.com/hashicorp/vault", "(devel)",
+				"-X github.com/hashicorp/vault/version.Version=v1.15.0 "+
+					"-X=github.com/hashicorp/vault/version.VersionPrerelease=rc1 "+
+					"-X 'github.com/hashicorp/vault/version.VersionMetadata=ent.fips1402'"),
+			expectedVersion: "1.15.0-rc1+ent.fips1402",
+		},
+		"main-module-version": {
+			bi:              testBuildInfo("github.com/hashicorp/vault", "v1.15.0", ""),
+			expectedVersion: "1.15.0",
+		},
+		"main-module-devel": {
+			bi:          testBuildInfo("github.com/hashicorp/vault", "(devel)", ""),
+			expectedErr: true,
+		},
+		"main-module-pseudo-version": {
+			bi:          testBuildInfo("github.com/hashicorp/vault", "v0.0.0-20231010101010-abcdefabcdef", ""),
+			expectedErr: true,
+		},
+		"other-main-module": {
+			bi:          testBuildInfo("github.com/example/vault", "v1.15.0", ""),
+			expectedErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			v, err := versionFromBuildInfo(testCase.bi, "github.com/hashicorp/vault/version")
+			if testCase.expectedErr {
+				if err == nil {
+					t.Fatalf("expected error, got version %s", v)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v.Original() != testCase.expectedVersion {
+				t.Fatalf("expected version %s, got %s", testCase.expectedVersion, v.Original())
+			}
+		})
+	}
+}
+
+func TestSplitQuoted(t *testing.T) {
+	t.Parallel()
+
+	args := splitQuoted(`-s  -X "a.B=c d" -X 'e.F=g'`)
+	expectedArgs := []string{"-s", "-X", "a.B=c d", "-X", "e.F=g"}
+	if diff := cmp.Diff(expectedArgs, args); diff != "" {
+		t.Fatalf("unexpected args: %s", diff)
+	}
+}
+
+func TestVersionFromBuildInfo_crossCompiled(t *testing.T) {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is not installed")
+	}
+
+	srcDir := t.TempDir()
+	writeTestFile(t, filepath.Join(srcDir, "go.mod"), "module example.com/tool\n\ngo 1.21\n")
+	writeTestFile(t, filepath.Join(srcDir, "version", "version.go"),
+		"package version\n\nvar (\n\tVersion string\n\tVersionMetadata string\n)\n")
+	writeTestFile(t, filepath.Join(srcDir, "main.go"),
+		"package main\n\nimport \"example.com/tool/version\"\n\nfunc main() { println(version.Version) }\n")
+
+	// binary for a different platform can't be executed,
+	// but its version can still be read
+	execPath := filepath.Join(t.TempDir(), "tool.exe")
+	cmd := exec.Command(goBin, "build", "-o", execPath, "-ldflags",
+		"-X example.com/tool/version.Version=1.2.3 -X example.com/tool/version.VersionMetadata=ent", ".")
+	cmd.Dir = srcDir
+	cmd.Env = append(os.Environ(), "GOOS=windows", "GOARCH=arm64", "CGO_ENABLED=0", "GOFLAGS=-mod=mod")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("failed to build test binary: %s: %s", err, out)
+	}
+
+	v, err := VersionFromBuildInfo(execPath, "example.com/tool/version")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Original() != "1.2.3+ent" {
+		t.Fatalf("unexpected version: %s", v.Original())
+	}
+}
+
+func testBuildInfo(mainPath, mainVersion, ldflags string) *debug.BuildInfo {
+	bi := &debug.BuildInfo{
+		Main: debug.Module{
+			Path:    mainPath,
+			Version: mainVersion,
+		},
+	}
+	if ldflags != "" {
+		bi.Settings = append(bi.Settings, debug.BuildSetting{
+			Key:   "-ldflags",
+			Value: ldflags,
+		})
+	}
+	return bi
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(path, []byte(content), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -37,6 +37,7 @@ var Consul = Product{
 		// We assume that error implies older version.
 		return legacyConsulVersion(ctx, path)
 	},
+	ReadVersion: buildInfoVersionReader("github.com/hashicorp/consul/version"),
 	BuildInstructions: &BuildInstructions{
 		GitRepoURL:    "https://github.com/hashicorp/consul.git",
 		PreCloneCheck: &build.GoIsInstalled{},
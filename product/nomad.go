@@ -46,6 +46,7 @@ var Nomad = Product{
 
 		return v, err
 	},
+	ReadVersion: buildInfoVersionReader("github.com/hashicorp/nomad/version"),
 	BuildInstructions: &BuildInstructions{
 		GitRepoURL:    "https://github.com/hashicorp/nomad.git",
 		PreCloneCheck: &build.GoIsInstalled{},
@@ -46,6 +46,7 @@ var Packer = Product{
 
 		return v, err
 	},
+	ReadVersion: buildInfoVersionReader("github.com/hashicorp/packer/version"),
 	BuildInstructions: &BuildInstructions{
 		GitRepoURL:    "https://github.com/hashicorp/packer.git",
 		PreCloneCheck: &build.GoIsInstalled{},
@@ -5,6 +5,7 @@ package product
 
 import (
 	"context"
+	"runtime"
 	"time"
 
 	"github.com/hashicorp/go-version"
@@ -20,16 +21,72 @@ type Product struct {
 	// BinaryName represents name of the unpacked binary to be executed or built
 	BinaryName BinaryNameFunc
 
+	// TargetBinaryName represents name of the binary depending on its
+	// version and platform (e.g. terraform-provider-foo_v1.2.3_x5)
+	// and takes precedence over BinaryName if set. It is only called
+	// once the version is known, so BinaryName (if set) represents
+	// the name when it isn't, e.g. when finding or building binaries.
+	TargetBinaryName TargetBinaryNameFunc
+
 	// GetVersion represents how to obtain the version of the product
 	// reflecting any output or CLI flag differences
 	GetVersion func(ctx context.Context, execPath string) (*version.Version, error)
 
+	// ReadVersion represents how to obtain the version of the product
+	// without executing the binary, e.g. from Go build info
+	// (nil if not supported)
+	ReadVersion func(ctx context.Context, execPath string) (*version.Version, error)
+
 	// BuildInstructions represents how to build the product "from scratch"
 	BuildInstructions *BuildInstructions
 }
 
 type BinaryNameFunc func() string
 
+type TargetBinaryNameFunc func(t Target) string
+
+// Target describes the binary whose name is requested
+type Target struct {
+	// Version is nil if it isn't known upfront, e.g. when validating
+	// a source, building a git reference, or finding binaries
+	// matching version constraints, in which case TargetBinaryName
+	// is never called
+	Version *version.Version
+
+	OS   string
+	Arch string
+}
+
+// CurrentTarget returns the target of the given version
+// for the platform hc-install is running on
+func CurrentTarget(v *version.Version) Target {
+	return Target{
+		Version: v,
+		OS:      runtime.GOOS,
+		Arch:    runtime.GOARCH,
+	}
+}
+
+// BinaryNameFor returns name of the binary for the given target
+// via TargetBinaryName (if the version is known), falling back
+// to BinaryName
+func (p Product) BinaryNameFor(t Target) string {
+	if p.TargetBinaryName != nil && t.Version != nil {
+		return p.TargetBinaryName(t)
+	}
+	if p.BinaryName != nil {
+		return p.BinaryName()
+	}
+	return ""
+}
+
+// BinaryNameKnown reports whether the name of the binary for the given
+// target can be determined, which isn't the case if it depends
+// on the version (without BinaryName declared) and the version isn't known
+func (p Product) BinaryNameKnown(t Target) bool {
+	return t.Version != nil || p.TargetBinaryName == nil || p.BinaryName != nil
+}
+
 type BuildInstructions struct {
 	GitRepoURL string
 
@@ -0,0 +1,92 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package product
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+)
+
+func TestProduct_BinaryNameFor(t *testing.T) {
+	t.Parallel()
+
+	target := Target{
+		Version: version.Must(version.NewVersion("1.2.3")),
+		OS:      "windows",
+		Arch:    "amd64",
+	}
+	targetBinaryName := func(t Target) string {
+		return fmt.Sprintf("foo_v%s_%s_%s.exe", t.Version, t.OS, t.Arch)
+	}
+
+	testCases := map[string]struct {
+		p              Product
+		unknownVersion bool
+		expectedName   string
+		expectUnknown  bool
+	}{
+		"BinaryName": {
+			p: Product{
+				BinaryName: func() string { return "foo" },
+			},
+			expectedName: "foo",
+		},
+		"TargetBinaryName": {
+			p: Product{
+				TargetBinaryName: targetBinaryName,
+			},
+			expectedName: "foo_v1.2.3_windows_amd64.exe",
+		},
+		"TargetBinaryName-preferred": {
+			p: Product{
+				BinaryName:       func() string { return "foo" },
+				TargetBinaryName: targetBinaryName,
+			},
+			expectedName: "foo_v1.2.3_windows_amd64.exe",
+		},
+		"TargetBinaryName-unknown-version": {
+			p: Product{
+				TargetBinaryName: targetBinaryName,
+			},
+			unknownVersion: true,
+			expectedName:   "",
+			expectUnknown:  true,
+		},
+		"TargetBinaryName-unknown-version-BinaryName": {
+			p: Product{
+				BinaryName:       func() string { return "foo" },
+				TargetBinaryName: targetBinaryName,
+			},
+			unknownVersion: true,
+			expectedName:   "foo",
+		},
+		"undeclared": {
+			p:            Product{},
+			expectedName: "",
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			target := target
+			if testCase.unknownVersion {
+				target.Version = nil
+			}
+
+			name := testCase.p.BinaryNameFor(target)
+			if name != testCase.expectedName {
+				t.Fatalf("expected name %q, got %q", testCase.expectedName, name)
+			}
+			if known := testCase.p.BinaryNameKnown(target); known == testCase.expectUnknown {
+				t.Fatalf("expected name to be known: %t, got %t", !testCase.expectUnknown, known)
+			}
+		})
+	}
+}
@@ -37,6 +37,7 @@ var Terraform = Product{
 		// We assume that error implies older version.
 		return legacyTerraformVersion(ctx, path)
 	},
+	ReadVersion: buildInfoVersionReader("github.com/hashicorp/terraform/version"),
 	BuildInstructions: &BuildInstructions{
 		GitRepoURL:    "https://github.com/hashicorp/terraform.git",
 		PreCloneCheck: &build.GoIsInstalled{},
@@ -48,6 +48,7 @@ var Vault = Product{
 
 		return v, err
 	},
+	ReadVersion: buildInfoVersionReader("github.com/hashicorp/vault/version"),
 	BuildInstructions: &BuildInstructions{
 		GitRepoURL:    "https://github.com/hashicorp/vault.git",
 		PreCloneCheck: &build.GoIsInstalled{},
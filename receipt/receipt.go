@@ -0,0 +1,150 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package receipt provides install receipts, i.e. JSON files placed
+// next to installed binaries, which record where the binary came from.
+package receipt
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	rjson "github.com/hashicorp/hc-install/releasesjson"
+	"github.com/hashicorp/hc-install/version"
+)
+
+// Suffix is appended to the path of the binary
+// to obtain the path of its receipt
+const Suffix = ".hc-install.json"
+
+// Receipt records the provenance of an installed binary
+type Receipt struct {
+	Product string `json:"product"`
+	Version string `json:"version"`
+	OS      string `json:"os"`
+	Arch    string `json:"arch"`
+
+	// SourceURL is the URL of the archive the binary was unpacked from
+	SourceURL string `json:"source_url,omitempty"`
+
+	// ArchiveSHA256 is the hex-encoded checksum of the archive
+	ArchiveSHA256 string `json:"archive_sha256,omitempty"`
+
+	// BinarySHA256 is the hex-encoded checksum of the binary
+	// at the time of installation
+	BinarySHA256 string `json:"binary_sha256"`
+
+	// SigningKeyFingerprint identifies the PGP key which signed
+	// the checksums, empty if checksum verification was skipped
+	SigningKeyFingerprint string `json:"signing_key_fingerprint,omitempty"`
+
+	InstalledAt time.Time `json:"installed_at"`
+
+	// InstallerVersion is the version of hc-install
+	// which installed the binary
+	InstallerVersion string `json:"installer_version"`
+}
+
+// FromUnpacked returns the receipt of the product version
+// whose archive was downloaded and unpacked by the downloader
+func FromUnpacked(productName, v string, up *rjson.UnpackedProduct) *Receipt {
+	r := &Receipt{
+		Product:               productName,
+		Version:               v,
+		SourceURL:             up.ArchiveURL,
+		ArchiveSHA256:         up.ArchiveChecksum.String(),
+		SigningKeyFingerprint: up.SigningKeyFingerprint,
+	}
+	if up.Build != nil {
+		r.OS = up.Build.OS
+		r.Arch = up.Build.Arch
+	}
+	return r
+}
+
+// Path returns the path of the receipt of the binary at execPath
+func Path(execPath string) string {
+	return execPath + Suffix
+}
+
+// Write writes the receipt next to the binary at execPath, computing
+// BinarySHA256 and filling in InstalledAt and InstallerVersion if empty.
+// It returns the path of the written receipt.
+func Write(execPath string, r *Receipt) (string, error) {
+	sum, err := fileSHA256(execPath)
+	if err != nil {
+		return "", err
+	}
+	r.BinarySHA256 = sum
+	if r.InstalledAt.IsZero() {
+		r.InstalledAt = time.Now().UTC()
+	}
+	if r.InstallerVersion == "" {
+		r.InstallerVersion = version.Version().String()
+	}
+
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	path := Path(execPath)
+	err = os.WriteFile(path, b, 0o644)
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Read reads the receipt of the binary at execPath, without verifying it.
+// Errors satisfy errors.Is(err, fs.ErrNotExist) if there is no receipt.
+func Read(execPath string) (*Receipt, error) {
+	b, err := os.ReadFile(Path(execPath))
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Receipt{}
+	err = json.Unmarshal(b, r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid receipt %q: %w", Path(execPath), err)
+	}
+	return r, nil
+}
+
+// Verify checks that the binary at execPath is the one
+// the receipt was written for, i.e. it has not changed since
+func (r *Receipt) Verify(execPath string) error {
+	if r.BinarySHA256 == "" {
+		return fmt.Errorf("receipt has no binary checksum")
+	}
+	sum, err := fileSHA256(execPath)
+	if err != nil {
+		return err
+	}
+	if sum != r.BinarySHA256 {
+		return fmt.Errorf("checksum mismatch of %s (expected: %s, got: %s)",
+			execPath, r.BinarySHA256, sum)
+	}
+	return nil
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
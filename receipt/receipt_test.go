@@ -0,0 +1,108 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package receipt
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	rjson "github.com/hashicorp/hc-install/releasesjson"
+	"github.com/hashicorp/hc-install/version"
+)
+
+func TestReceipt(t *testing.T) {
+	t.Parallel()
+
+	execPath := filepath.Join(t.TempDir(), "terraform")
+	err := os.WriteFile(execPath, []byte("binary"), 0o700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Read(execPath)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected missing receipt, got: %v", err)
+	}
+
+	path, err := Write(execPath, &Receipt{
+		Product: "terraform",
+		Version: "1.5.0",
+		OS:      "linux",
+		Arch:    "amd64",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != execPath+".hc-install.json" {
+		t.Fatalf("unexpected receipt path: %q", path)
+	}
+
+	r, err := Read(execPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedReceipt := &Receipt{
+		Product:          "terraform",
+		Version:          "1.5.0",
+		OS:               "linux",
+		Arch:             "amd64",
+		BinarySHA256:     "9a3a45d01531a20e89ac6ae10b0b0beb0492acd7216a368aa062d1a5fecaf9cd",
+		InstalledAt:      r.InstalledAt,
+		InstallerVersion: version.Version().String(),
+	}
+	if diff := cmp.Diff(expectedReceipt, r); diff != "" {
+		t.Fatalf("unexpected receipt: %s", diff)
+	}
+	if r.InstalledAt.IsZero() {
+		t.Fatal("expected installation time to be recorded")
+	}
+
+	err = r.Verify(execPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(execPath, []byte("modified"), 0o700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.Verify(execPath)
+	if err == nil {
+		t.Fatal("expected modified binary to fail verification")
+	}
+}
+
+func TestFromUnpacked(t *testing.T) {
+	t.Parallel()
+
+	up := &rjson.UnpackedProduct{
+		Build: &rjson.ProductBuild{
+			Name:    "terraform",
+			Version: "1.5.0",
+			OS:      "linux",
+			Arch:    "amd64",
+		},
+		ArchiveURL:            "https://releases.hashicorp.com/terraform/1.5.0/terraform_1.5.0_linux_amd64.zip",
+		ArchiveChecksum:       rjson.HashSum{0xde, 0xad, 0xbe, 0xef},
+		SigningKeyFingerprint: "C874011F0AB405110D02105534365D9472D7468F",
+	}
+
+	r := FromUnpacked("terraform", "1.5.0", up)
+	expectedReceipt := &Receipt{
+		Product:               "terraform",
+		Version:               "1.5.0",
+		OS:                    "linux",
+		Arch:                  "amd64",
+		SourceURL:             "https://releases.hashicorp.com/terraform/1.5.0/terraform_1.5.0_linux_amd64.zip",
+		ArchiveSHA256:         "deadbeef",
+		SigningKeyFingerprint: "C874011F0AB405110D02105534365D9472D7468F",
+	}
+	if diff := cmp.Diff(expectedReceipt, r); diff != "" {
+		t.Fatalf("unexpected receipt: %s", diff)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hc-install/internal/tempdir"
+)
+
+// ErrChanged is wrapped by errors describing items which were changed
+// or replaced since they were created, and are therefore not removed
+var ErrChanged = errors.New("changed since installed")
+
+// Item describes a file or directory created by an install, along with
+// its state right after the install, such that it is only removed
+// if nobody changed or replaced it since
+type Item struct {
+	Path  string `json:"path"`
+	IsDir bool   `json:"is_dir,omitempty"`
+
+	// Size and ModTime are only recorded for files
+	Size    int64 `json:"size,omitempty"`
+	ModTime int64 `json:"mod_time,omitempty"`
+
+	// BackupPath is the path of a file which existed at Path before
+	// the install and is restored when the item is removed
+	BackupPath string `json:"backup_path,omitempty"`
+}
+
+// RemovalPlan describes what removal of items would do
+type RemovalPlan struct {
+	// Remove are paths which would be removed
+	Remove []string
+
+	// Restore are paths which would be restored from their backups
+	Restore []string
+
+	// Keep are paths which would be kept, because they were
+	// changed or replaced since they were created
+	Keep []*KeptPath
+}
+
+// KeptPath describes a path which would be kept by removal
+type KeptPath struct {
+	Path string
+	Err  error
+}
+
+// NewItems describes paths created by an install in their current
+// state, skipping any which don't exist (e.g. after a failed install).
+// backupPaths maps paths of files overwritten by the install
+// to paths of their backups.
+func NewItems(paths []string, backupPaths map[string]string) []*Item {
+	items := make([]*Item, 0, len(paths))
+	seen := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+
+		fi, err := os.Lstat(path)
+		if err != nil {
+			continue
+		}
+		item := &Item{
+			Path:       path,
+			IsDir:      fi.IsDir(),
+			BackupPath: backupPaths[path],
+		}
+		if !item.IsDir {
+			item.Size = fi.Size()
+			item.ModTime = fi.ModTime().UnixNano()
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// Check returns an error wrapping ErrChanged if the item was changed
+// or replaced since it was created, or fs.ErrNotExist if it was removed
+func (i *Item) Check() error {
+	fi, err := os.Lstat(i.Path)
+	if err != nil {
+		return err
+	}
+	if fi.IsDir() != i.IsDir {
+		return fmt.Errorf("%q: %w (file type differs)", i.Path, ErrChanged)
+	}
+	if i.IsDir {
+		return nil
+	}
+	if fi.Size() != i.Size || fi.ModTime().UnixNano() != i.ModTime {
+		return fmt.Errorf("%q: %w (size or modification time differs)", i.Path, ErrChanged)
+	}
+	return nil
+}
+
+// PlanRemoval determines what RemoveItems would do,
+// without changing anything
+func PlanRemoval(items []*Item) *RemovalPlan {
+	plan := &RemovalPlan{
+		Remove:  make([]string, 0),
+		Restore: make([]string, 0),
+		Keep:    make([]*KeptPath, 0),
+	}
+	for _, item := range items {
+		err := item.Check()
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			plan.Keep = append(plan.Keep, &KeptPath{Path: item.Path, Err: err})
+			continue
+		}
+		if err == nil {
+			plan.Remove = append(plan.Remove, item.Path)
+		}
+		if item.BackupPath != "" && fileExists(item.BackupPath) {
+			plan.Restore = append(plan.Restore, item.Path)
+		}
+	}
+	return plan
+}
+
+// RemoveItems removes items in the given order and restores any
+// backups. Items which were changed or replaced since they were
+// created are kept and reported as errors wrapping ErrChanged.
+func RemoveItems(items []*Item, logger *log.Logger) error {
+	if logger == nil {
+		logger = discardLogger
+	}
+
+	var errs *multierror.Error
+	for _, item := range items {
+		err := item.Check()
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			logger.Printf("refusing to remove %s: %s", item.Path, err)
+			errs = multierror.Append(errs, fmt.Errorf("refusing to remove: %w", err))
+			continue
+		}
+		if err == nil {
+			logger.Printf("removing %s", item.Path)
+			err = tempdir.RemoveAll(item.Path)
+			if err != nil {
+				errs = multierror.Append(errs, err)
+				continue
+			}
+		}
+		if item.BackupPath != "" && fileExists(item.BackupPath) {
+			logger.Printf("restoring %s from %s", item.Path, item.BackupPath)
+			err = os.Rename(item.BackupPath, item.Path)
+			if err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
@@ -0,0 +1,91 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package registry
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hc-install/internal/testutil"
+)
+
+func TestRemoveItems(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	createdPath := createFile(t, filepath.Join(dir, "created"))
+	changedPath := createFile(t, filepath.Join(dir, "changed"))
+	overwrittenPath := createFile(t, filepath.Join(dir, "overwritten"))
+	backupPath := filepath.Join(dir, "overwritten.backup")
+	err := os.WriteFile(backupPath, []byte("original"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items := NewItems([]string{
+		createdPath,
+		changedPath,
+		overwrittenPath,
+		filepath.Join(dir, "missing"),
+	}, map[string]string{
+		overwrittenPath: backupPath,
+	})
+	if len(items) != 3 {
+		t.Fatalf("expected missing path to be skipped, got %d items", len(items))
+	}
+
+	// replaced by someone else after the install
+	err = os.WriteFile(changedPath, []byte("replaced"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chtimes(changedPath, time.Now(), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan := PlanRemoval(items)
+	if diff := cmp.Diff([]string{createdPath, overwrittenPath}, plan.Remove); diff != "" {
+		t.Fatalf("unexpected paths to remove: %s", diff)
+	}
+	if diff := cmp.Diff([]string{overwrittenPath}, plan.Restore); diff != "" {
+		t.Fatalf("unexpected paths to restore: %s", diff)
+	}
+	if len(plan.Keep) != 1 || plan.Keep[0].Path != changedPath || !errors.Is(plan.Keep[0].Err, ErrChanged) {
+		t.Fatalf("expected only %q to be kept, got %#v", changedPath, plan.Keep)
+	}
+	if _, err := os.Stat(createdPath); err != nil {
+		t.Fatalf("expected planning to keep %q: %s", createdPath, err)
+	}
+
+	err = RemoveItems(items, testutil.TestLogger())
+	if !errors.Is(err, ErrChanged) {
+		t.Fatalf("expected error about changed file, got: %v", err)
+	}
+
+	if _, err := os.Stat(createdPath); !os.IsNotExist(err) {
+		t.Fatalf("expected %q to be removed, got: %v", createdPath, err)
+	}
+	b, err := os.ReadFile(changedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "replaced" {
+		t.Fatalf("expected changed file to be kept, got %q", string(b))
+	}
+	b, err = os.ReadFile(overwrittenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "original" {
+		t.Fatalf("expected overwritten file to be restored, got %q", string(b))
+	}
+	if _, err := os.Stat(backupPath); !os.IsNotExist(err) {
+		t.Fatalf("expected backup to be moved back, got: %v", err)
+	}
+}
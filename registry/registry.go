@@ -0,0 +1,355 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package registry provides an on-disk record of files created by
+// installs, such that they can be removed by another process,
+// e.g. after the process which installed them restarted.
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/internal/atomicfile"
+)
+
+var discardLogger = log.New(io.Discard, "", 0)
+
+// ErrNotFound is returned by Remove when no installs
+// of the given product version are recorded
+var ErrNotFound = errors.New("no recorded installs found")
+
+// Settable is implemented by sources which can record
+// files they create in a registry, such that they can be removed
+// by another process
+type Settable interface {
+	SetRegistry(r *Registry)
+}
+
+// RemovalPlanner is implemented by removable sources which can
+// describe what Remove would do, without removing anything
+type RemovalPlanner interface {
+	PlanRemove(ctx context.Context) (*RemovalPlan, error)
+}
+
+// Registry records which files each install created. Entries are keyed
+// by product and path to the binary, such that installing again into
+// the same location replaces the entry, keeping the replaced one
+// as Entry.Previous until the new install is removed.
+//
+// The registry may be shared by multiple processes.
+type Registry struct {
+	// Dir represents the directory where entries are stored
+	Dir string
+
+	logger *log.Logger
+}
+
+// Entry describes files created by a single install
+type Entry struct {
+	Product string `json:"product"`
+	Version string `json:"version"`
+
+	// ExecPath is the path to the installed binary
+	ExecPath string `json:"exec_path"`
+
+	// Items are all files and directories created by the install
+	// (including ExecPath), in order of removal
+	Items []*Item `json:"items"`
+
+	InstalledAt time.Time `json:"installed_at"`
+
+	// Previous is the entry of an earlier install into the same
+	// location, which this install replaced (and whose files it may
+	// have backed up). It is recorded again once this install is removed.
+	Previous *Entry `json:"previous,omitempty"`
+}
+
+// DefaultDir returns the default directory of the registry,
+// within the configuration directory of the current user
+func DefaultDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "hc-install", "registry"), nil
+}
+
+func (r *Registry) SetLogger(logger *log.Logger) {
+	r.logger = logger
+}
+
+func (r *Registry) log() *log.Logger {
+	if r.logger == nil {
+		return discardLogger
+	}
+	return r.logger
+}
+
+// Record stores the entry, filling in InstalledAt if empty.
+// Paths are made absolute, such that the entry can be used
+// from a different working directory. Any entry of an earlier
+// install into the same location becomes Previous.
+func (r *Registry) Record(e *Entry) error {
+	if e.Product == "" || e.ExecPath == "" {
+		return fmt.Errorf("entry requires product and exec path")
+	}
+	var err error
+	e.ExecPath, err = filepath.Abs(e.ExecPath)
+	if err != nil {
+		return err
+	}
+	for _, item := range e.Items {
+		item.Path, err = filepath.Abs(item.Path)
+		if err != nil {
+			return err
+		}
+		if item.BackupPath != "" {
+			item.BackupPath, err = filepath.Abs(item.BackupPath)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if e.InstalledAt.IsZero() {
+		e.InstalledAt = time.Now().UTC()
+	}
+
+	if e.Previous == nil {
+		e.Previous, err = r.load(e.Product, e.ExecPath)
+		if err != nil {
+			r.log().Printf("replacing invalid entry of %s: %s", e.ExecPath, err)
+		}
+	}
+
+	err = r.write(e)
+	if err != nil {
+		return err
+	}
+	r.log().Printf("recorded install of %s@%s at %s", e.Product, e.Version, e.ExecPath)
+
+	return nil
+}
+
+// RecordInstall records items created by an install
+// of the product version and returns the recorded entry
+func (r *Registry) RecordInstall(productName string, v *version.Version, execPath string, items []*Item) (*Entry, error) {
+	e := &Entry{
+		Product:  productName,
+		Version:  v.String(),
+		ExecPath: execPath,
+		Items:    items,
+	}
+	err := r.Record(e)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (r *Registry) write(e *Entry) error {
+	b, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(r.Dir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	return atomicfile.Write(r.entryPath(e.Product, e.ExecPath), b)
+}
+
+// load returns the entry of the given binary, or nil if there's none
+func (r *Registry) load(productName, execPath string) (*Entry, error) {
+	b, err := os.ReadFile(r.entryPath(productName, execPath))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	e := &Entry{}
+	err = json.Unmarshal(b, e)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// List returns all recorded entries
+func (r *Registry) List() ([]*Entry, error) {
+	dirEntries, err := os.ReadDir(r.Dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return []*Entry{}, nil
+		}
+		return nil, err
+	}
+
+	entries := make([]*Entry, 0)
+	for _, de := range dirEntries {
+		if de.IsDir() || strings.HasPrefix(de.Name(), ".") ||
+			filepath.Ext(de.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(r.Dir, de.Name())
+		b, err := os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				// removed concurrently
+				continue
+			}
+			return nil, err
+		}
+		e := &Entry{}
+		err = json.Unmarshal(b, e)
+		if err != nil {
+			r.log().Printf("ignoring invalid entry %s: %s", path, err)
+			continue
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+// Find returns entries of the given product version. The version
+// has to match exactly, including any metadata (e.g. +ent).
+func (r *Registry) Find(productName string, v *version.Version) ([]*Entry, error) {
+	entries, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+
+	found := make([]*Entry, 0)
+	for _, e := range entries {
+		if e.Product != productName {
+			continue
+		}
+		if sameVersion(e, v) {
+			found = append(found, e)
+		}
+	}
+
+	return found, nil
+}
+
+// Delete deletes the entry without removing any of its paths.
+// The entry it replaced (if any) is recorded again, since removal
+// of the items restores files of that install.
+func (r *Registry) Delete(e *Entry) error {
+	current, err := r.load(e.Product, e.ExecPath)
+	if err != nil {
+		r.log().Printf("deleting invalid entry of %s: %s", e.ExecPath, err)
+		current = nil
+	}
+
+	if current != nil && !current.InstalledAt.Equal(e.InstalledAt) {
+		// replaced by another install since, which keeps the entry
+		// as (possibly indirect) Previous
+		for c := current; c.Previous != nil; c = c.Previous {
+			if c.Previous.InstalledAt.Equal(e.InstalledAt) {
+				c.Previous = c.Previous.Previous
+				return r.write(current)
+			}
+		}
+		return nil
+	}
+	if current != nil && current.Previous != nil {
+		return r.write(current.Previous)
+	}
+
+	err = os.Remove(r.entryPath(e.Product, e.ExecPath))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// PlanRemove determines what Remove would do, without changing anything
+func (r *Registry) PlanRemove(productName string, v *version.Version) (*RemovalPlan, error) {
+	entries, err := r.Find(productName, v)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%s@%s: %w", productName, v, ErrNotFound)
+	}
+
+	items := make([]*Item, 0)
+	for _, e := range entries {
+		for ; e != nil && sameVersion(e, v); e = e.Previous {
+			items = append(items, e.Items...)
+		}
+	}
+	return PlanRemoval(items), nil
+}
+
+// Remove removes all items recorded for installs of the given product
+// version, along with their entries, and returns the removed entries.
+// Entries whose items can't all be removed (e.g. because they were
+// changed since the install, see RemoveItems) are kept. Earlier
+// installs of the same version into the same location (see
+// Entry.Previous) are removed too, whereas those of other versions
+// are recorded again.
+func (r *Registry) Remove(ctx context.Context, productName string, v *version.Version) ([]*Entry, error) {
+	entries, err := r.Find(productName, v)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%s@%s: %w", productName, v, ErrNotFound)
+	}
+
+	var errs *multierror.Error
+	removed := make([]*Entry, 0, len(entries))
+	for _, e := range entries {
+		if err := ctx.Err(); err != nil {
+			return removed, err
+		}
+		for ; e != nil && sameVersion(e, v); e = e.Previous {
+			err := r.RemoveEntry(e)
+			if err != nil {
+				errs = multierror.Append(errs, err)
+				break
+			}
+			removed = append(removed, e)
+		}
+	}
+
+	return removed, errs.ErrorOrNil()
+}
+
+// RemoveEntry removes all items of the entry and then the entry itself
+func (r *Registry) RemoveEntry(e *Entry) error {
+	err := RemoveItems(e.Items, r.log())
+	if err != nil {
+		return err
+	}
+	return r.Delete(e)
+}
+
+func sameVersion(e *Entry, v *version.Version) bool {
+	ev, err := version.NewVersion(e.Version)
+	return err == nil && ev.String() == v.String()
+}
+
+func (r *Registry) entryPath(productName, execPath string) string {
+	sum := sha256.Sum256([]byte(productName + "\x00" + execPath))
+	return filepath.Join(r.Dir, hex.EncodeToString(sum[:])+".json")
+}
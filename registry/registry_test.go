@@ -0,0 +1,229 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/internal/testutil"
+)
+
+func TestRegistry_Remove(t *testing.T) {
+	t.Parallel()
+
+	installDir := t.TempDir()
+	r := &Registry{Dir: t.TempDir()}
+	r.SetLogger(testutil.TestLogger())
+
+	ossPath := createFile(t, filepath.Join(installDir, "oss", "vault"))
+	entPath := createFile(t, filepath.Join(installDir, "ent", "vault"))
+	licensePath := createFile(t, filepath.Join(installDir, "ent", "LICENSE.txt"))
+
+	err := r.Record(&Entry{
+		Product:  "vault",
+		Version:  "1.15.0",
+		ExecPath: ossPath,
+		Items:    NewItems([]string{ossPath}, nil),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// recording the same binary again replaces the entry
+	// (keeping the replaced one as Previous)
+	err = r.Record(&Entry{
+		Product:  "vault",
+		Version:  "1.15.0",
+		ExecPath: ossPath,
+		Items:    NewItems([]string{ossPath}, nil),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.Record(&Entry{
+		Product:  "vault",
+		Version:  "1.15.0+ent",
+		ExecPath: entPath,
+		Items:    NewItems([]string{entPath, licensePath}, nil),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := r.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+
+	ctx := context.Background()
+	_, err = r.Remove(ctx, "vault", version.Must(version.NewVersion("1.14.0")))
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+
+	removed, err := r.Remove(ctx, "vault", version.Must(version.NewVersion("1.15.0+ent")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != 1 || removed[0].ExecPath != entPath {
+		t.Fatalf("expected only %q to be removed, got %#v", entPath, removed)
+	}
+	for _, path := range []string{entPath, licensePath} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Fatalf("expected %q to be removed, got: %v", path, err)
+		}
+	}
+	if _, err := os.Stat(ossPath); err != nil {
+		t.Fatalf("expected %q to be kept: %s", ossPath, err)
+	}
+
+	entries, err = r.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Version != "1.15.0" {
+		t.Fatalf("expected only 1.15.0 entry to be kept, got %#v", entries)
+	}
+}
+
+func TestRegistry_RecordInstall(t *testing.T) {
+	t.Parallel()
+
+	r := &Registry{Dir: t.TempDir()}
+	r.SetLogger(testutil.TestLogger())
+
+	execPath := createFile(t, filepath.Join(t.TempDir(), "vault"))
+	v := version.Must(version.NewVersion("1.15.0+ent"))
+
+	e, err := r.RecordInstall("vault", v, execPath, NewItems([]string{execPath}, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Version != "1.15.0+ent" || e.InstalledAt.IsZero() {
+		t.Fatalf("unexpected entry: %#v", e)
+	}
+
+	entries, err := r.Find("vault", v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].ExecPath != execPath {
+		t.Fatalf("expected entry of %q, got %#v", execPath, entries)
+	}
+}
+
+func TestRegistry_Remove_previous(t *testing.T) {
+	t.Parallel()
+
+	installDir := t.TempDir()
+	r := &Registry{Dir: t.TempDir()}
+	r.SetLogger(testutil.TestLogger())
+
+	// each install moves the binary of the previous one aside
+	execPath := filepath.Join(installDir, "vault")
+	err := os.WriteFile(execPath, []byte("original"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range []string{"1.14.0", "1.15.0"} {
+		backupPath := fmt.Sprintf("%s.backup%d", execPath, i)
+		err := os.Rename(execPath, backupPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(execPath, []byte(v), 0o755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = r.Record(&Entry{
+			Product:  "vault",
+			Version:  v,
+			ExecPath: execPath,
+			Items:    NewItems([]string{execPath}, map[string]string{execPath: backupPath}),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	assertContent := func(expected string) {
+		t.Helper()
+		b, err := os.ReadFile(execPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != expected {
+			t.Fatalf("expected %q, got %q", expected, b)
+		}
+	}
+
+	// only the latest install is in place
+	_, err = r.Remove(ctx, "vault", version.Must(version.NewVersion("1.14.0")))
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+
+	_, err = r.Remove(ctx, "vault", version.Must(version.NewVersion("1.15.0")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertContent("1.14.0")
+
+	// the replaced install is recorded again
+	entries, err := r.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Version != "1.14.0" {
+		t.Fatalf("expected 1.14.0 entry to be recorded again, got %#v", entries)
+	}
+
+	_, err = r.Remove(ctx, "vault", version.Must(version.NewVersion("1.14.0")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertContent("original")
+
+	entries, err = r.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected no entries, got %d", len(entries))
+	}
+}
+
+func TestRegistry_List_missingDir(t *testing.T) {
+	t.Parallel()
+
+	r := &Registry{Dir: filepath.Join(t.TempDir(), "missing")}
+	entries, err := r.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected no entries, got %d", len(entries))
+	}
+}
+
+func createFile(t *testing.T, path string) string {
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(path, []byte("test"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
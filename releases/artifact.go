@@ -0,0 +1,10 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package releases
+
+import "github.com/hashicorp/hc-install/src"
+
+// Artifact describes the release archive which was downloaded
+// and unpacked during the last successful installation
+type Artifact = src.Artifact
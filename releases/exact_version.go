@@ -7,16 +7,24 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hc-install/internal/pubkey"
-	rjson "github.com/hashicorp/hc-install/internal/releasesjson"
+	"github.com/hashicorp/hc-install/internal/release"
 	isrc "github.com/hashicorp/hc-install/internal/src"
+	"github.com/hashicorp/hc-install/internal/tempdir"
 	"github.com/hashicorp/hc-install/internal/validators"
+	"github.com/hashicorp/hc-install/internal/versioncheck"
+	"github.com/hashicorp/hc-install/policy"
 	"github.com/hashicorp/hc-install/product"
+	"github.com/hashicorp/hc-install/receipt"
+	"github.com/hashicorp/hc-install/registry"
+	rjson "github.com/hashicorp/hc-install/releasesjson"
+	"github.com/hashicorp/hc-install/src"
 )
 
 // ExactVersion installs the given Version of product
@@ -36,16 +44,67 @@ type ExactVersion struct {
 
 	SkipChecksumVerification bool
 
+	// WriteReceipt indicates that an install receipt recording
+	// the provenance of the binary (see package receipt)
+	// should be written next to it
+	WriteReceipt bool
+
+	// VerifyVersion indicates that the installed binary has to report
+	// the installed version (including any enterprise metadata) via
+	// Product.GetVersion, e.g. to detect mislabeled archives served
+	// by a mirror. *errors.VersionMismatchError is returned otherwise,
+	// once the install is rolled back (restoring any overwritten files).
+	VerifyVersion bool
+
+	// PreferBuildInfo indicates that VerifyVersion reads the version
+	// without executing the binary (via Product.ReadVersion, e.g. from
+	// Go build info), falling back to executing it via Product.GetVersion
+	PreferBuildInfo bool
+
+	// Registry represents an optional on-disk registry where files created
+	// by the install are recorded, such that they can be removed
+	// by another process (see package registry)
+	Registry *registry.Registry
+
 	// ArmoredPublicKey is a public PGP key in ASCII/armor format to use
 	// instead of built-in pubkey to verify signature of downloaded checksums
 	ArmoredPublicKey string
 
+	// AdditionalArmoredPublicKeys are public PGP keys in ASCII/armor format
+	// which are trusted alongside the built-in pubkey (or ArmoredPublicKey),
+	// e.g. during key rotation or when a mirror re-signs the checksums
+	AdditionalArmoredPublicKeys []string
+
+	// Sigstore enables verification of Sigstore bundle of the checksums
+	// in addition to the PGP signature (leave nil to verify PGP only)
+	Sigstore *SigstoreOptions
+
 	// ApiBaseURL is an optional field that specifies a custom URL to download the product from.
 	// If ApiBaseURL is set, the product will be downloaded from this base URL instead of the default site.
 	// Note: The directory structure of the custom URL must match the HashiCorp releases site (including the index.json files).
-	ApiBaseURL    string
-	logger        *log.Logger
-	pathsToRemove []string
+	ApiBaseURL string
+
+	// IndexCache represents an optional on-disk cache of index.json files
+	// which are revalidated using conditional requests
+	IndexCache *rjson.IndexCache
+
+	// HTTPClient represents an optional client used for all requests,
+	// e.g. to configure proxy or TLS settings of a mirror
+	HTTPClient *http.Client
+
+	// Policy represents an optional policy which has to allow
+	// the version before it is downloaded
+	Policy policy.Policy
+
+	logger          *log.Logger
+	pathsToRemove   []string
+	artifact        *Artifact
+	installerPolicy policy.Policy
+
+	installerRegistry *registry.Registry
+	registryEntry     *registry.Entry
+	backupPaths       map[string]string
+	removableItems    []*registry.Item
 }
 
 func (*ExactVersion) IsSourceImpl() isrc.InstallSrcSigil {
@@ -56,6 +115,27 @@ func (ev *ExactVersion) SetLogger(logger *log.Logger) {
 	ev.logger = logger
 }
 
+// SetPolicy sets a policy which is consulted in addition to Policy
+func (ev *ExactVersion) SetPolicy(p policy.Policy) {
+	ev.installerPolicy = p
+}
+
+// SetRegistry sets a registry which is used if Registry is not set
+func (ev *ExactVersion) SetRegistry(reg *registry.Registry) {
+	ev.installerRegistry = reg
+}
+
+func (ev *ExactVersion) registry() *registry.Registry {
+	if ev.Registry != nil {
+		return ev.Registry
+	}
+	return ev.installerRegistry
+}
+
+func (ev *ExactVersion) policies() policy.Policies {
+	return policy.Policies{ev.Policy, ev.installerPolicy}
+}
+
 func (ev *ExactVersion) log() *log.Logger {
 	if ev.logger == nil {
 		return discardLogger
@@ -68,8 +148,15 @@ func (ev *ExactVersion) Validate() error {
 		return fmt.Errorf("invalid product name: %q", ev.Product.Name)
 	}
 
-	if !validators.IsBinaryNameValid(ev.Product.BinaryName()) {
-		return fmt.Errorf("invalid binary name: %q", ev.Product.BinaryName())
+	binaryName := ev.Product.BinaryNameFor(product.CurrentTarget(ev.installVersion()))
+	if !validators.IsBinaryNameValid(binaryName) {
+		return fmt.Errorf("invalid binary name: %q", binaryName)
+	}
+
+	if ev.VerifyVersion {
+		if err := versioncheck.Validate(ev.Product, ev.PreferBuildInfo); err != nil {
+			return err
+		}
 	}
 
 	if ev.Version == nil {
@@ -80,10 +167,35 @@ func (ev *ExactVersion) Validate() error {
 		return err
 	}
 
+	if err := release.ValidateSigstoreOptions(ev.Sigstore); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// Install installs the product and records what it created
+// (even if it failed), such that Remove removes only that
 func (ev *ExactVersion) Install(ctx context.Context) (string, error) {
+	firstPath := len(ev.pathsToRemove)
+	execPath, err := ev.install(ctx)
+	items := registry.NewItems(ev.pathsToRemove[firstPath:], ev.backupPaths)
+	ev.removableItems = append(ev.removableItems, versioncheck.RollBack(err, items, ev.log())...)
+	if err != nil {
+		return "", err
+	}
+
+	if reg := ev.registry(); reg != nil {
+		ev.registryEntry, err = reg.RecordInstall(ev.Product.Name, ev.artifact.Version, execPath, items)
+		if err != nil {
+			return "", fmt.Errorf("failed to record install: %w", err)
+		}
+	}
+
+	return execPath, nil
+}
+
+func (ev *ExactVersion) install(ctx context.Context) (string, error) {
 	timeout := defaultInstallTimeout
 	if ev.Timeout > 0 {
 		timeout = ev.Timeout
@@ -91,15 +203,23 @@ func (ev *ExactVersion) Install(ctx context.Context) (string, error) {
 	ctx, cancelFunc := context.WithTimeout(ctx, timeout)
 	defer cancelFunc()
 
+	err := ev.checkPolicy(ctx)
+	if err != nil {
+		return "", err
+	}
+
 	if ev.pathsToRemove == nil {
 		ev.pathsToRemove = make([]string, 0)
 	}
+	if ev.backupPaths == nil {
+		ev.backupPaths = make(map[string]string)
+	}
 
 	dstDir := ev.InstallDir
 	if dstDir == "" {
 		var err error
 		dirName := fmt.Sprintf("%s_*", ev.Product.Name)
-		dstDir, err = os.MkdirTemp("", dirName)
+		dstDir, err = tempdir.MkdirTemp(dirName)
 		if err != nil {
 			return "", err
 		}
@@ -108,43 +228,27 @@ func (ev *ExactVersion) Install(ctx context.Context) (string, error) {
 	}
 	ev.log().Printf("will install into dir at %s", dstDir)
 
-	rels := rjson.NewReleases()
-	if ev.ApiBaseURL != "" {
-		rels.BaseURL = ev.ApiBaseURL
-	}
-	rels.SetLogger(ev.log())
-	installVersion := ev.Version
-	if ev.Enterprise != nil {
-		installVersion = versionWithMetadata(installVersion, enterpriseVersionMetadata(ev.Enterprise))
-	}
-	pv, err := rels.GetProductVersion(ctx, ev.Product.Name, installVersion)
+	pv, err := ev.productVersion(ctx, ev.IndexCache)
 	if err != nil {
 		return "", err
 	}
 
-	d := &rjson.Downloader{
-		Logger:           ev.log(),
-		VerifyChecksum:   !ev.SkipChecksumVerification,
-		ArmoredPublicKey: pubkey.DefaultPublicKey,
-		BaseURL:          rels.BaseURL,
-	}
-	if ev.ArmoredPublicKey != "" {
-		d.ArmoredPublicKey = ev.ArmoredPublicKey
-	}
-	if ev.ApiBaseURL != "" {
-		d.BaseURL = ev.ApiBaseURL
-	}
-
+	d := ev.downloader()
 	licenseDir := ev.LicenseDir
 	up, err := d.DownloadAndUnpack(ctx, pv, dstDir, licenseDir)
 	if up != nil {
 		ev.pathsToRemove = append(ev.pathsToRemove, up.PathsToRemove...)
+		// overwritten files are restored by Remove
+		for path, backupPath := range up.BackupPaths {
+			ev.pathsToRemove = append(ev.pathsToRemove, path)
+			ev.backupPaths[path] = backupPath
+		}
 	}
 	if err != nil {
 		return "", err
 	}
 
-	execPath := filepath.Join(dstDir, ev.Product.BinaryName())
+	execPath := filepath.Join(dstDir, ev.Product.BinaryNameFor(product.CurrentTarget(pv.Version)))
 
 	ev.pathsToRemove = append(ev.pathsToRemove, execPath)
 
@@ -154,22 +258,134 @@ func (ev *ExactVersion) Install(ctx context.Context) (string, error) {
 		return "", err
 	}
 
+	if ev.VerifyVersion {
+		err = versioncheck.Check(ctx, ev.log(), ev.Product, ev.PreferBuildInfo, execPath, pv.Version)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	ev.artifact = release.NewArtifact(pv.Version, up, execPath)
+
+	if ev.WriteReceipt {
+		r := receipt.FromUnpacked(ev.Product.Name, pv.Version.String(), up)
+		receiptPath, err := receipt.Write(execPath, r)
+		if err != nil {
+			return "", err
+		}
+		ev.pathsToRemove = append(ev.pathsToRemove, receiptPath)
+		ev.artifact.ExtraFiles = append(ev.artifact.ExtraFiles, receiptPath)
+		ev.log().Printf("wrote install receipt to %s", receiptPath)
+	}
+
 	return execPath, nil
 }
 
+// Artifact returns details of the archive obtained by the last
+// successful Install, or nil if nothing was installed yet
+func (ev *ExactVersion) Artifact() *Artifact {
+	return ev.artifact
+}
+
+// Resolve determines the archive which would be installed,
+// without downloading it. IndexCache is not used, so that
+// no filesystem changes are made.
+func (ev *ExactVersion) Resolve(ctx context.Context) (*src.Resolution, error) {
+	timeout := defaultInstallTimeout
+	if ev.Timeout > 0 {
+		timeout = ev.Timeout
+	}
+	ctx, cancelFunc := context.WithTimeout(ctx, timeout)
+	defer cancelFunc()
+
+	err := ev.checkPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pv, err := ev.productVersion(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return release.Resolution(ev.downloader(), pv, ev.InstallDir,
+		ev.Product.BinaryNameFor(product.CurrentTarget(pv.Version)))
+}
+
+func (ev *ExactVersion) productVersion(ctx context.Context, cache *rjson.IndexCache) (*rjson.ProductVersion, error) {
+	rels := rjson.NewReleases()
+	if ev.ApiBaseURL != "" {
+		rels.BaseURL = ev.ApiBaseURL
+	}
+	rels.Cache = cache
+	rels.HTTPClient = ev.HTTPClient
+	rels.SetLogger(ev.log())
+	return rels.GetProductVersion(ctx, ev.Product.Name, ev.installVersion())
+}
+
+// checkPolicy checks the version against policies
+// before anything is requested from the releases site
+func (ev *ExactVersion) checkPolicy(ctx context.Context) error {
+	return ev.policies().Check(ctx, &policy.Candidate{
+		Product:           ev.Product.Name,
+		Version:           ev.installVersion(),
+		SignatureVerified: !ev.SkipChecksumVerification,
+	})
+}
+
+func (ev *ExactVersion) installVersion() *version.Version {
+	if ev.Enterprise != nil {
+		return versionWithMetadata(ev.Version, enterpriseVersionMetadata(ev.Enterprise))
+	}
+	return ev.Version
+}
+
+func (ev *ExactVersion) downloader() *rjson.Downloader {
+	d := &rjson.Downloader{
+		HTTPClient:                  ev.HTTPClient,
+		Logger:                      ev.log(),
+		VerifyChecksum:              !ev.SkipChecksumVerification,
+		ArmoredPublicKey:            pubkey.DefaultPublicKey,
+		BaseURL:                     rjson.NewReleases().BaseURL,
+		AdditionalArmoredPublicKeys: ev.AdditionalArmoredPublicKeys,
+	}
+	if ev.ArmoredPublicKey != "" {
+		d.ArmoredPublicKey = ev.ArmoredPublicKey
+	}
+	if ev.ApiBaseURL != "" {
+		d.BaseURL = ev.ApiBaseURL
+	}
+	d.ChecksumVerifiers = release.ChecksumVerifiers(ev.Sigstore, d.ArmoredPublicKey,
+		ev.AdditionalArmoredPublicKeys, ev.log())
+	return d
+}
+
+// Remove removes files created by Install and restores any files
+// they overwrote. Files which were changed or replaced since
+// they were installed are kept (see registry.RemoveItems).
 func (ev *ExactVersion) Remove(ctx context.Context) error {
-	if ev.pathsToRemove != nil {
-		for _, path := range ev.pathsToRemove {
-			err := os.RemoveAll(path)
-			if err != nil {
-				return err
-			}
+	err := registry.RemoveItems(ev.removableItems, ev.log())
+	if err != nil {
+		return err
+	}
+	ev.removableItems = nil
+
+	if ev.registryEntry != nil {
+		err := ev.registry().Delete(ev.registryEntry)
+		if err != nil {
+			return err
 		}
+		ev.registryEntry = nil
 	}
 
 	return nil
 }
 
+// PlanRemove describes what Remove would do, without removing anything
+func (ev *ExactVersion) PlanRemove(ctx context.Context) (*registry.RemovalPlan, error) {
+	return registry.PlanRemoval(ev.removableItems), nil
+}
+
 // versionWithMetadata returns a new version by combining the given version with the given metadata
 func versionWithMetadata(v *version.Version, metadata string) *version.Version {
 	if v == nil {
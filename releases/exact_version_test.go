@@ -56,6 +56,17 @@ func TestExactVersionValidate(t *testing.T) {
 			},
 			expectedErr: fmt.Errorf("LicenseDir must be provided when requesting enterprise versions"),
 		},
+		"VerifyVersion-without-getter": {
+			ev: ExactVersion{
+				Product: product.Product{
+					BinaryName: product.Terraform.BinaryName,
+					Name:       product.Terraform.Name,
+				},
+				Version:       version.Must(version.NewVersion("1.0.0")),
+				VerifyVersion: true,
+			},
+			expectedErr: fmt.Errorf("undeclared version getter"),
+		},
 	}
 
 	for name, testCase := range testCases {
@@ -7,17 +7,26 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"path/filepath"
 	"sort"
 	"time"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hc-install/internal/pubkey"
-	rjson "github.com/hashicorp/hc-install/internal/releasesjson"
+	"github.com/hashicorp/hc-install/internal/release"
 	isrc "github.com/hashicorp/hc-install/internal/src"
+	"github.com/hashicorp/hc-install/internal/tempdir"
 	"github.com/hashicorp/hc-install/internal/validators"
+	"github.com/hashicorp/hc-install/internal/versioncheck"
+	"github.com/hashicorp/hc-install/policy"
 	"github.com/hashicorp/hc-install/product"
+	"github.com/hashicorp/hc-install/receipt"
+	"github.com/hashicorp/hc-install/registry"
+	rjson "github.com/hashicorp/hc-install/releasesjson"
+	"github.com/hashicorp/hc-install/src"
 )
 
 type LatestVersion struct {
@@ -36,16 +45,77 @@ type LatestVersion struct {
 
 	SkipChecksumVerification bool
 
+	// WriteReceipt indicates that an install receipt recording
+	// the provenance of the binary (see package receipt)
+	// should be written next to it
+	WriteReceipt bool
+
+	// VerifyVersion indicates that the installed binary has to report
+	// the installed version (including any enterprise metadata) via
+	// Product.GetVersion, e.g. to detect mislabeled archives served
+	// by a mirror. *errors.VersionMismatchError is returned otherwise,
+	// once the install is rolled back (restoring any overwritten files).
+	VerifyVersion bool
+
+	// PreferBuildInfo indicates that VerifyVersion reads the version
+	// without executing the binary (via Product.ReadVersion, e.g. from
+	// Go build info), falling back to executing it via Product.GetVersion
+	PreferBuildInfo bool
+
+	// Registry represents an optional on-disk registry where files created
+	// by the install are recorded, such that they can be removed
+	// by another process (see package registry)
+	Registry *registry.Registry
+
 	// ArmoredPublicKey is a public PGP key in ASCII/armor format to use
 	// instead of built-in pubkey to verify signature of downloaded checksums
 	ArmoredPublicKey string
 
+	// AdditionalArmoredPublicKeys are public PGP keys in ASCII/armor format
+	// which are trusted alongside the built-in pubkey (or ArmoredPublicKey),
+	// e.g. during key rotation or when a mirror re-signs the checksums
+	AdditionalArmoredPublicKeys []string
+
+	// Sigstore enables verification of Sigstore bundle of the checksums
+	// in addition to the PGP signature (leave nil to verify PGP only)
+	Sigstore *SigstoreOptions
+
 	// ApiBaseURL is an optional field that specifies a custom URL to download the product from.
 	// If ApiBaseURL is set, the product will be downloaded from this base URL instead of the default site.
 	// Note: The directory structure of the custom URL must match the HashiCorp releases site (including the index.json files).
-	ApiBaseURL    string
-	logger        *log.Logger
-	pathsToRemove []string
+	ApiBaseURL string
+
+	// ReleasesAPIBaseURL enables listing of versions via the structured
+	// releases API (e.g. https://api.releases.hashicorp.com) instead of
+	// index.json. The API provides release metadata, such that withdrawn
+	// versions are skipped. Archives are still downloaded from ApiBaseURL.
+	ReleasesAPIBaseURL string
+
+	// ReleasedBefore limits installation to versions released
+	// before the given time (requires ReleasesAPIBaseURL)
+	ReleasedBefore time.Time
+
+	// IndexCache represents an optional on-disk cache of index.json files
+	// which are revalidated using conditional requests
+	IndexCache *rjson.IndexCache
+
+	// HTTPClient represents an optional client used for all requests,
+	// e.g. to configure proxy or TLS settings of a mirror
+	HTTPClient *http.Client
+
+	// Policy represents an optional policy which has to allow
+	// the version before it is downloaded
+	Policy policy.Policy
+
+	logger          *log.Logger
+	pathsToRemove   []string
+	artifact        *Artifact
+	installerPolicy policy.Policy
+
+	installerRegistry *registry.Registry
+	registryEntry     *registry.Entry
+	backupPaths       map[string]string
+	removableItems    []*registry.Item
 }
 
 func (*LatestVersion) IsSourceImpl() isrc.InstallSrcSigil {
@@ -56,6 +126,27 @@ func (lv *LatestVersion) SetLogger(logger *log.Logger) {
 	lv.logger = logger
 }
 
+// SetPolicy sets a policy which is consulted in addition to Policy
+func (lv *LatestVersion) SetPolicy(p policy.Policy) {
+	lv.installerPolicy = p
+}
+
+// SetRegistry sets a registry which is used if Registry is not set
+func (lv *LatestVersion) SetRegistry(reg *registry.Registry) {
+	lv.installerRegistry = reg
+}
+
+func (lv *LatestVersion) registry() *registry.Registry {
+	if lv.Registry != nil {
+		return lv.Registry
+	}
+	return lv.installerRegistry
+}
+
+func (lv *LatestVersion) policies() policy.Policies {
+	return policy.Policies{lv.Policy, lv.installerPolicy}
+}
+
 func (lv *LatestVersion) log() *log.Logger {
 	if lv.logger == nil {
 		return discardLogger
@@ -68,18 +159,57 @@ func (lv *LatestVersion) Validate() error {
 		return fmt.Errorf("invalid product name: %q", lv.Product.Name)
 	}
 
-	if !validators.IsBinaryNameValid(lv.Product.BinaryName()) {
-		return fmt.Errorf("invalid binary name: %q", lv.Product.BinaryName())
+	// name may depend on the version, which isn't known yet
+	if target := product.CurrentTarget(nil); lv.Product.BinaryNameKnown(target) {
+		binaryName := lv.Product.BinaryNameFor(target)
+		if !validators.IsBinaryNameValid(binaryName) {
+			return fmt.Errorf("invalid binary name: %q", binaryName)
+		}
+	}
+
+	if lv.VerifyVersion {
+		if err := versioncheck.Validate(lv.Product, lv.PreferBuildInfo); err != nil {
+			return err
+		}
 	}
 
 	if err := validateEnterpriseOptions(lv.Enterprise, lv.LicenseDir); err != nil {
 		return err
 	}
 
+	if err := release.ValidateSigstoreOptions(lv.Sigstore); err != nil {
+		return err
+	}
+
+	if !lv.ReleasedBefore.IsZero() && lv.ReleasesAPIBaseURL == "" {
+		return fmt.Errorf("ReleasesAPIBaseURL must be provided when filtering by ReleasedBefore")
+	}
+
 	return nil
 }
 
+// Install installs the product and records what it created
+// (even if it failed), such that Remove removes only that
 func (lv *LatestVersion) Install(ctx context.Context) (string, error) {
+	firstPath := len(lv.pathsToRemove)
+	execPath, err := lv.install(ctx)
+	items := registry.NewItems(lv.pathsToRemove[firstPath:], lv.backupPaths)
+	lv.removableItems = append(lv.removableItems, versioncheck.RollBack(err, items, lv.log())...)
+	if err != nil {
+		return "", err
+	}
+
+	if reg := lv.registry(); reg != nil {
+		lv.registryEntry, err = reg.RecordInstall(lv.Product.Name, lv.artifact.Version, execPath, items)
+		if err != nil {
+			return "", fmt.Errorf("failed to record install: %w", err)
+		}
+	}
+
+	return execPath, nil
+}
+
+func (lv *LatestVersion) install(ctx context.Context) (string, error) {
 	timeout := defaultInstallTimeout
 	if lv.Timeout > 0 {
 		timeout = lv.Timeout
@@ -90,12 +220,24 @@ func (lv *LatestVersion) Install(ctx context.Context) (string, error) {
 	if lv.pathsToRemove == nil {
 		lv.pathsToRemove = make([]string, 0)
 	}
+	if lv.backupPaths == nil {
+		lv.backupPaths = make(map[string]string)
+	}
+
+	versionToInstall, err := lv.resolveVersion(ctx, lv.IndexCache)
+	if err != nil {
+		return "", err
+	}
+
+	binaryName := lv.Product.BinaryNameFor(product.CurrentTarget(versionToInstall.Version))
+	if !validators.IsBinaryNameValid(binaryName) {
+		return "", fmt.Errorf("invalid binary name: %q", binaryName)
+	}
 
 	dstDir := lv.InstallDir
 	if dstDir == "" {
-		var err error
 		dirName := fmt.Sprintf("%s_*", lv.Product.Name)
-		dstDir, err = os.MkdirTemp("", dirName)
+		dstDir, err = tempdir.MkdirTemp(dirName)
 		if err != nil {
 			return "", err
 		}
@@ -104,47 +246,22 @@ func (lv *LatestVersion) Install(ctx context.Context) (string, error) {
 	}
 	lv.log().Printf("will install into dir at %s", dstDir)
 
-	rels := rjson.NewReleases()
-	if lv.ApiBaseURL != "" {
-		rels.BaseURL = lv.ApiBaseURL
-	}
-	rels.SetLogger(lv.log())
-	versions, err := rels.ListProductVersions(ctx, lv.Product.Name)
-	if err != nil {
-		return "", err
-	}
-
-	if len(versions) == 0 {
-		return "", fmt.Errorf("no versions found for %q", lv.Product.Name)
-	}
-
-	versionToInstall, ok := lv.findLatestMatchingVersion(versions, lv.Constraints)
-	if !ok {
-		return "", fmt.Errorf("no matching version found for %q", lv.Constraints)
-	}
-
-	d := &rjson.Downloader{
-		Logger:           lv.log(),
-		VerifyChecksum:   !lv.SkipChecksumVerification,
-		ArmoredPublicKey: pubkey.DefaultPublicKey,
-		BaseURL:          rels.BaseURL,
-	}
-	if lv.ArmoredPublicKey != "" {
-		d.ArmoredPublicKey = lv.ArmoredPublicKey
-	}
-	if lv.ApiBaseURL != "" {
-		d.BaseURL = lv.ApiBaseURL
-	}
+	d := lv.downloader()
 	licenseDir := lv.LicenseDir
 	up, err := d.DownloadAndUnpack(ctx, versionToInstall, dstDir, licenseDir)
 	if up != nil {
 		lv.pathsToRemove = append(lv.pathsToRemove, up.PathsToRemove...)
+		// overwritten files are restored by Remove
+		for path, backupPath := range up.BackupPaths {
+			lv.pathsToRemove = append(lv.pathsToRemove, path)
+			lv.backupPaths[path] = backupPath
+		}
 	}
 	if err != nil {
 		return "", err
 	}
 
-	execPath := filepath.Join(dstDir, lv.Product.BinaryName())
+	execPath := filepath.Join(dstDir, binaryName)
 
 	lv.pathsToRemove = append(lv.pathsToRemove, execPath)
 
@@ -154,22 +271,135 @@ func (lv *LatestVersion) Install(ctx context.Context) (string, error) {
 		return "", err
 	}
 
+	if lv.VerifyVersion {
+		err = versioncheck.Check(ctx, lv.log(), lv.Product, lv.PreferBuildInfo, execPath, versionToInstall.Version)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	lv.artifact = release.NewArtifact(versionToInstall.Version, up, execPath)
+
+	if lv.WriteReceipt {
+		r := receipt.FromUnpacked(lv.Product.Name, versionToInstall.Version.String(), up)
+		receiptPath, err := receipt.Write(execPath, r)
+		if err != nil {
+			return "", err
+		}
+		lv.pathsToRemove = append(lv.pathsToRemove, receiptPath)
+		lv.artifact.ExtraFiles = append(lv.artifact.ExtraFiles, receiptPath)
+		lv.log().Printf("wrote install receipt to %s", receiptPath)
+	}
+
 	return execPath, nil
 }
 
+// Artifact returns details of the archive obtained by the last
+// successful Install, or nil if nothing was installed yet
+func (lv *LatestVersion) Artifact() *Artifact {
+	return lv.artifact
+}
+
+// Remove removes files created by Install and restores any files
+// they overwrote. Files which were changed or replaced since
+// they were installed are kept (see registry.RemoveItems).
 func (lv *LatestVersion) Remove(ctx context.Context) error {
-	if lv.pathsToRemove != nil {
-		for _, path := range lv.pathsToRemove {
-			err := os.RemoveAll(path)
-			if err != nil {
-				return err
-			}
+	err := registry.RemoveItems(lv.removableItems, lv.log())
+	if err != nil {
+		return err
+	}
+	lv.removableItems = nil
+
+	if lv.registryEntry != nil {
+		err := lv.registry().Delete(lv.registryEntry)
+		if err != nil {
+			return err
 		}
+		lv.registryEntry = nil
 	}
+
 	return nil
 }
 
-func (lv *LatestVersion) findLatestMatchingVersion(pvs rjson.ProductVersionsMap, vc version.Constraints) (*rjson.ProductVersion, bool) {
+// PlanRemove describes what Remove would do, without removing anything
+func (lv *LatestVersion) PlanRemove(ctx context.Context) (*registry.RemovalPlan, error) {
+	return registry.PlanRemoval(lv.removableItems), nil
+}
+
+// Resolve determines the latest matching version and the archive
+// which would be installed, without downloading it. IndexCache
+// is not used, so that no filesystem changes are made.
+func (lv *LatestVersion) Resolve(ctx context.Context) (*src.Resolution, error) {
+	timeout := defaultInstallTimeout
+	if lv.Timeout > 0 {
+		timeout = lv.Timeout
+	}
+	ctx, cancelFunc := context.WithTimeout(ctx, timeout)
+	defer cancelFunc()
+
+	pv, err := lv.resolveVersion(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return release.Resolution(lv.downloader(), pv, lv.InstallDir,
+		lv.Product.BinaryNameFor(product.CurrentTarget(pv.Version)))
+}
+
+func (lv *LatestVersion) resolveVersion(ctx context.Context, cache *rjson.IndexCache) (*rjson.ProductVersion, error) {
+	versions, err := lv.listVersions(ctx, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("no versions found for %q", lv.Product.Name)
+	}
+
+	return lv.findLatestMatchingVersion(ctx, versions, lv.Constraints)
+}
+
+func (lv *LatestVersion) downloader() *rjson.Downloader {
+	d := &rjson.Downloader{
+		HTTPClient:                  lv.HTTPClient,
+		Logger:                      lv.log(),
+		VerifyChecksum:              !lv.SkipChecksumVerification,
+		ArmoredPublicKey:            pubkey.DefaultPublicKey,
+		BaseURL:                     rjson.NewReleases().BaseURL,
+		AdditionalArmoredPublicKeys: lv.AdditionalArmoredPublicKeys,
+	}
+	if lv.ArmoredPublicKey != "" {
+		d.ArmoredPublicKey = lv.ArmoredPublicKey
+	}
+	if lv.ApiBaseURL != "" {
+		d.BaseURL = lv.ApiBaseURL
+	}
+	d.ChecksumVerifiers = release.ChecksumVerifiers(lv.Sigstore, d.ArmoredPublicKey,
+		lv.AdditionalArmoredPublicKeys, lv.log())
+	return d
+}
+
+func (lv *LatestVersion) listVersions(ctx context.Context, cache *rjson.IndexCache) (rjson.ProductVersionsMap, error) {
+	if lv.ReleasesAPIBaseURL != "" {
+		api := rjson.NewReleasesAPI()
+		api.BaseURL = lv.ReleasesAPIBaseURL
+		api.HTTPClient = lv.HTTPClient
+		api.SetLogger(lv.log())
+		return api.ListProductVersions(ctx, lv.Product.Name)
+	}
+
+	rels := rjson.NewReleases()
+	if lv.ApiBaseURL != "" {
+		rels.BaseURL = lv.ApiBaseURL
+	}
+	rels.Cache = cache
+	rels.HTTPClient = lv.HTTPClient
+	rels.SetLogger(lv.log())
+	return rels.ListProductVersions(ctx, lv.Product.Name)
+}
+
+func (lv *LatestVersion) findLatestMatchingVersion(ctx context.Context, pvs rjson.ProductVersionsMap, vc version.Constraints) (*rjson.ProductVersion, error) {
+	var denials *multierror.Error
 	expectedMetadata := enterpriseVersionMetadata(lv.Enterprise)
 	versions := make(version.Collection, 0)
 	for _, pv := range pvs.AsSlice() {
@@ -182,17 +412,39 @@ func (lv *LatestVersion) findLatestMatchingVersion(pvs rjson.ProductVersionsMap,
 			continue
 		}
 
-		if vc.Check(pv.Version) {
-			versions = append(versions, pv.Version)
+		if pv.Metadata != nil && pv.Metadata.IsWithdrawn() {
+			lv.log().Printf("skipping withdrawn version %s", pv.Version)
+			continue
 		}
+
+		if !lv.ReleasedBefore.IsZero() &&
+			(pv.Metadata == nil || !pv.Metadata.Created.Before(lv.ReleasedBefore)) {
+			continue
+		}
+
+		if !vc.Check(pv.Version) {
+			continue
+		}
+
+		err := lv.policies().Check(ctx, policyCandidate(lv.Product, pv, lv.SkipChecksumVerification))
+		if err != nil {
+			lv.log().Printf("skipping version %s: %s", pv.Version, err)
+			denials = multierror.Append(denials, err)
+			continue
+		}
+
+		versions = append(versions, pv.Version)
 	}
 
 	if len(versions) == 0 {
-		return nil, false
+		if denials != nil {
+			return nil, fmt.Errorf("no matching version allowed by policy for %q: %w", vc, denials)
+		}
+		return nil, fmt.Errorf("no matching version found for %q", vc)
 	}
 
 	sort.Stable(versions)
 	latestVersion := versions[len(versions)-1]
 
-	return pvs[latestVersion.Original()], true
+	return pvs[latestVersion.Original()], nil
 }
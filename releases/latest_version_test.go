@@ -4,12 +4,14 @@
 package releases
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-version"
-	rjson "github.com/hashicorp/hc-install/internal/releasesjson"
 	"github.com/hashicorp/hc-install/product"
+	rjson "github.com/hashicorp/hc-install/releasesjson"
 )
 
 func TestLatestVersionValidate(t *testing.T) {
@@ -49,6 +51,13 @@ func TestLatestVersionValidate(t *testing.T) {
 			},
 			expectedErr: fmt.Errorf("LicenseDir must be provided when requesting enterprise versions"),
 		},
+		"ReleasedBefore-missing-releases-api": {
+			lv: LatestVersion{
+				Product:        product.Terraform,
+				ReleasedBefore: time.Date(2021, 5, 15, 0, 0, 0, 0, time.UTC),
+			},
+			expectedErr: fmt.Errorf("ReleasesAPIBaseURL must be provided when filtering by ReleasedBefore"),
+		},
 	}
 
 	for name, testCase := range testCases {
@@ -136,7 +145,7 @@ func TestLatestVersion_FindLatestMatchingVersion(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			latest, _ := testCase.lv.findLatestMatchingVersion(possibleVersions, testCase.lv.Constraints)
+			latest, _ := testCase.lv.findLatestMatchingVersion(context.Background(), possibleVersions, testCase.lv.Constraints)
 
 			if latest.Version.Original() != testCase.expectedVersion {
 				t.Fatalf("expected version %s, got %s", testCase.expectedVersion, latest.Version.Original())
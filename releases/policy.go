@@ -0,0 +1,18 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package releases
+
+import (
+	"github.com/hashicorp/hc-install/policy"
+	"github.com/hashicorp/hc-install/product"
+	rjson "github.com/hashicorp/hc-install/releasesjson"
+)
+
+func policyCandidate(p product.Product, pv *rjson.ProductVersion, skipChecksumVerification bool) *policy.Candidate {
+	return &policy.Candidate{
+		Product:           p.Name,
+		Version:           pv.Version,
+		SignatureVerified: !skipChecksumVerification,
+	}
+}
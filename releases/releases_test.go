@@ -5,15 +5,27 @@ package releases
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-version"
+	hcerrors "github.com/hashicorp/hc-install/errors"
+	"github.com/hashicorp/hc-install/internal/pubkey"
 	"github.com/hashicorp/hc-install/internal/testutil"
+	"github.com/hashicorp/hc-install/policy"
 	"github.com/hashicorp/hc-install/product"
+	"github.com/hashicorp/hc-install/receipt"
+	"github.com/hashicorp/hc-install/registry"
+	rjson "github.com/hashicorp/hc-install/releasesjson"
 	"github.com/hashicorp/hc-install/src"
 )
 
@@ -23,6 +35,9 @@ var (
 
 	_ src.Installable = &LatestVersion{}
 	_ src.Removable   = &LatestVersion{}
+
+	_ src.Resolvable = &ExactVersion{}
+	_ src.Resolvable = &LatestVersion{}
 )
 
 func TestLatestVersion(t *testing.T) {
@@ -86,6 +101,59 @@ func TestLatestVersion_basic(t *testing.T) {
 		t.Fatalf("versions don't match (expected: %s, installed: %s)",
 			expectedVersion, v)
 	}
+
+	artifact := lv.Artifact()
+	if artifact == nil {
+		t.Fatal("expected artifact details after installation")
+	}
+	if !expectedVersion.Equal(artifact.Version) {
+		t.Fatalf("unexpected artifact version (expected: %s, given: %s)",
+			expectedVersion, artifact.Version)
+	}
+	if len(artifact.SHA256) != 64 {
+		t.Fatalf("unexpected archive checksum: %q", artifact.SHA256)
+	}
+	if !strings.HasSuffix(artifact.SigningKeyID, "2FCA0A85") {
+		t.Fatalf("unexpected signing key ID: %q", artifact.SigningKeyID)
+	}
+}
+
+func TestLatestVersion_releasesAPI(t *testing.T) {
+	mockApiRoot := filepath.Join("testdata", "mock_api_tf_0_14_with_prereleases")
+	mockReleasesAPIRoot := filepath.Join("testdata", "mock_releases_api")
+
+	lv := &LatestVersion{
+		Product:            product.Terraform,
+		Constraints:        version.MustConstraints(version.NewConstraint("~> 0.14.0")),
+		ArmoredPublicKey:   getTestPubKey(t),
+		ApiBaseURL:         testutil.NewTestServer(t, mockApiRoot).URL,
+		ReleasesAPIBaseURL: testutil.NewReleasesAPITestServer(t, mockReleasesAPIRoot).URL,
+		// 0.14.12 is withdrawn and 0.14.13 was released later
+		ReleasedBefore: time.Date(2021, 5, 15, 0, 0, 0, 0, time.UTC),
+	}
+	lv.SetLogger(testutil.TestLogger())
+
+	ctx := context.Background()
+
+	execPath, err := lv.Install(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { lv.Remove(ctx) })
+
+	v, err := product.Terraform.GetVersion(ctx, execPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedVersion, err := version.NewVersion("0.14.11")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !expectedVersion.Equal(v) {
+		t.Fatalf("versions don't match (expected: %s, installed: %s)",
+			expectedVersion, v)
+	}
 }
 
 func TestLatestVersion_prereleases(t *testing.T) {
@@ -122,6 +190,52 @@ func TestLatestVersion_prereleases(t *testing.T) {
 	}
 }
 
+func TestLatestVersion_policy(t *testing.T) {
+	mockApiRoot := filepath.Join("testdata", "mock_api_tf_0_14_with_prereleases")
+	mockReleasesAPIRoot := filepath.Join("testdata", "mock_releases_api")
+
+	lv := &LatestVersion{
+		Product:            product.Terraform,
+		ApiBaseURL:         testutil.NewTestServer(t, mockApiRoot).URL,
+		ReleasesAPIBaseURL: testutil.NewReleasesAPITestServer(t, mockReleasesAPIRoot).URL,
+		// 0.14.12 is withdrawn, so 0.14.11 is the latest allowed version
+		Policy: &policy.DenyVersions{
+			Constraints: []version.Constraints{
+				version.MustConstraints(version.NewConstraint("0.14.13")),
+			},
+			Reason: "known vulnerability",
+		},
+	}
+	lv.SetLogger(testutil.TestLogger())
+
+	ctx := context.Background()
+	r, err := lv.Resolve(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Version.String() != "0.14.11" {
+		t.Fatalf("unexpected version: %s", r.Version)
+	}
+
+	// policy set by the installer is consulted in addition
+	lv.SetPolicy(&policy.VersionRange{
+		Min: version.Must(version.NewVersion("0.15.0")),
+	})
+	_, err = lv.Resolve(ctx)
+	if !policy.IsDenied(err) {
+		t.Fatalf("expected all versions to be denied, got: %v", err)
+	}
+
+	// denied install has no side effects
+	_, err = lv.Install(ctx)
+	if !policy.IsDenied(err) {
+		t.Fatalf("expected install to be denied, got: %v", err)
+	}
+	if len(lv.pathsToRemove) != 0 {
+		t.Fatalf("expected no paths to be created, got: %q", lv.pathsToRemove)
+	}
+}
+
 func TestExactVersion(t *testing.T) {
 	testutil.EndToEndTest(t)
 
@@ -168,6 +282,530 @@ func TestExactVersion(t *testing.T) {
 	}
 }
 
+func TestExactVersion_additionalPublicKeys(t *testing.T) {
+	mockApiRoot := filepath.Join("testdata", "mock_api_tf_0_14_with_prereleases")
+
+	// the default key did not sign any of the mock builds
+	// so the test key has to be trusted in addition to it
+	ev := &ExactVersion{
+		Product:                     product.Terraform,
+		Version:                     version.Must(version.NewVersion("0.14.11")),
+		ArmoredPublicKey:            pubkey.DefaultPublicKey,
+		AdditionalArmoredPublicKeys: []string{getTestPubKey(t)},
+		ApiBaseURL:                  testutil.NewTestServer(t, mockApiRoot).URL,
+	}
+	ev.SetLogger(testutil.TestLogger())
+
+	ctx := context.Background()
+	_, err := ev.Install(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ev.Remove(ctx) })
+
+	artifact := ev.Artifact()
+	if !strings.HasSuffix(artifact.SigningKeyID, "2FCA0A85") {
+		t.Fatalf("unexpected signing key ID: %q", artifact.SigningKeyID)
+	}
+	if !strings.HasSuffix(artifact.SigningKeyFingerprint, artifact.SigningKeyID) {
+		t.Fatalf("unexpected signing key fingerprint: %q", artifact.SigningKeyFingerprint)
+	}
+}
+
+func TestExactVersion_receipt(t *testing.T) {
+	mockApiRoot := filepath.Join("testdata", "mock_api_tf_0_14_with_prereleases")
+	srv := testutil.NewTestServer(t, mockApiRoot)
+
+	ev := &ExactVersion{
+		Product:          product.Terraform,
+		Version:          version.Must(version.NewVersion("0.14.11")),
+		ArmoredPublicKey: getTestPubKey(t),
+		ApiBaseURL:       srv.URL,
+		InstallDir:       t.TempDir(),
+		WriteReceipt:     true,
+	}
+	ev.SetLogger(testutil.TestLogger())
+
+	ctx := context.Background()
+	execPath, err := ev.Install(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := receipt.Read(execPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.Verify(execPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	artifact := ev.Artifact()
+	expectedReceipt := &receipt.Receipt{
+		Product:               "terraform",
+		Version:               "0.14.11",
+		OS:                    "linux",
+		Arch:                  "amd64",
+		SourceURL:             srv.URL + "/terraform/0.14.11/terraform_0.14.11_linux_amd64.zip",
+		ArchiveSHA256:         artifact.SHA256,
+		BinarySHA256:          r.BinarySHA256,
+		SigningKeyFingerprint: artifact.SigningKeyFingerprint,
+		InstalledAt:           r.InstalledAt,
+		InstallerVersion:      r.InstallerVersion,
+	}
+	if diff := cmp.Diff(expectedReceipt, r); diff != "" {
+		t.Fatalf("unexpected receipt: %s", diff)
+	}
+	if !slices.Contains(artifact.ExtraFiles, receipt.Path(execPath)) {
+		t.Fatalf("expected receipt among extra files: %q", artifact.ExtraFiles)
+	}
+
+	err = ev.Remove(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(receipt.Path(execPath)); !os.IsNotExist(err) {
+		t.Fatalf("expected receipt to be removed, got: %v", err)
+	}
+}
+
+func TestExactVersion_registry(t *testing.T) {
+	mockApiRoot := filepath.Join("testdata", "mock_api_tf_0_14_with_prereleases")
+	srv := testutil.NewTestServer(t, mockApiRoot)
+
+	registryDir := t.TempDir()
+	v := version.Must(version.NewVersion("0.14.11"))
+	newSource := func() *ExactVersion {
+		ev := &ExactVersion{
+			Product:          product.Terraform,
+			Version:          v,
+			ArmoredPublicKey: getTestPubKey(t),
+			ApiBaseURL:       srv.URL,
+			WriteReceipt:     true,
+			Registry:         &registry.Registry{Dir: registryDir},
+		}
+		ev.SetLogger(testutil.TestLogger())
+		return ev
+	}
+
+	ctx := context.Background()
+
+	// removal via source also deletes the entry
+	ev := newSource()
+	_, err := ev.Install(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ev.Remove(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := ev.Registry.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected no entries after removal, got %d", len(entries))
+	}
+
+	// removal via registry, as if from another process
+	execPath, err := newSource().Install(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg := &registry.Registry{Dir: registryDir}
+	reg.SetLogger(testutil.TestLogger())
+	removed, err := reg.Remove(ctx, "terraform", v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != 1 {
+		t.Fatalf("expected 1 removed entry, got %d", len(removed))
+	}
+	expectedPaths := []string{
+		filepath.Dir(execPath),
+		execPath,
+		receipt.Path(execPath),
+	}
+	recordedPaths := make([]string, 0)
+	for _, item := range removed[0].Items {
+		recordedPaths = append(recordedPaths, item.Path)
+	}
+	for _, path := range expectedPaths {
+		if !slices.Contains(recordedPaths, path) {
+			t.Fatalf("expected %q among recorded paths: %q", path, recordedPaths)
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Fatalf("expected %q to be removed, got: %v", path, err)
+		}
+	}
+}
+
+func TestExactVersion_registry_installTwice(t *testing.T) {
+	mockApiRoot := filepath.Join("testdata", "mock_api_tf_0_14_with_prereleases")
+	srv := testutil.NewTestServer(t, mockApiRoot)
+
+	installDir := t.TempDir()
+	existingPath := filepath.Join(installDir, "terraform")
+	err := os.WriteFile(existingPath, []byte("existing"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registryDir := t.TempDir()
+	v := version.Must(version.NewVersion("0.14.11"))
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		ev := &ExactVersion{
+			Product:          product.Terraform,
+			Version:          v,
+			ArmoredPublicKey: getTestPubKey(t),
+			ApiBaseURL:       srv.URL,
+			InstallDir:       installDir,
+			Registry:         &registry.Registry{Dir: registryDir},
+		}
+		ev.SetLogger(testutil.TestLogger())
+		_, err := ev.Install(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// uninstall, as if from another process
+	reg := &registry.Registry{Dir: registryDir}
+	reg.SetLogger(testutil.TestLogger())
+	removed, err := reg.Remove(ctx, "terraform", v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != 2 {
+		t.Fatalf("expected both installs to be removed, got %d", len(removed))
+	}
+
+	b, err := os.ReadFile(existingPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "existing" {
+		t.Fatalf("expected overwritten file to be restored, got %d bytes", len(b))
+	}
+	entries, err := os.ReadDir(installDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected only the restored file to be left, found %d entries", len(entries))
+	}
+	recorded, err := reg.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recorded) != 0 {
+		t.Fatalf("expected no entries after removal, got %d", len(recorded))
+	}
+}
+
+func TestExactVersion_overwrite(t *testing.T) {
+	mockApiRoot := filepath.Join("testdata", "mock_api_tf_0_14_with_prereleases")
+	srv := testutil.NewTestServer(t, mockApiRoot)
+
+	installDir := t.TempDir()
+	existingPath := filepath.Join(installDir, "terraform")
+	err := os.WriteFile(existingPath, []byte("existing"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ev := &ExactVersion{
+		Product:          product.Terraform,
+		Version:          version.Must(version.NewVersion("0.14.11")),
+		ArmoredPublicKey: getTestPubKey(t),
+		ApiBaseURL:       srv.URL,
+		InstallDir:       installDir,
+	}
+	ev.SetLogger(testutil.TestLogger())
+
+	ctx := context.Background()
+	execPath, err := ev.Install(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if execPath != existingPath {
+		t.Fatalf("expected %q to be overwritten, got %q", existingPath, execPath)
+	}
+	if _, err := os.Stat(existingPath + rjson.BackupSuffix); err != nil {
+		t.Fatalf("expected backup of overwritten file: %s", err)
+	}
+
+	plan, err := ev.PlanRemove(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(plan.Remove, execPath) || !slices.Contains(plan.Restore, execPath) {
+		t.Fatalf("expected %q to be removed and restored, got %#v", execPath, plan)
+	}
+
+	err = ev.Remove(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(existingPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "existing" {
+		t.Fatalf("expected overwritten file to be restored, got %d bytes", len(b))
+	}
+	if _, err := os.Stat(existingPath + rjson.BackupSuffix); !os.IsNotExist(err) {
+		t.Fatalf("expected backup to be moved back, got: %v", err)
+	}
+}
+
+func TestExactVersion_overwrite_existingBackup(t *testing.T) {
+	mockApiRoot := filepath.Join("testdata", "mock_api_tf_0_14_with_prereleases")
+	srv := testutil.NewTestServer(t, mockApiRoot)
+
+	installDir := t.TempDir()
+	existingPath := filepath.Join(installDir, "terraform")
+	err := os.WriteFile(existingPath, []byte("existing"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// e.g. left behind by a previous install which wasn't removed
+	staleBackupPath := existingPath + rjson.BackupSuffix
+	err = os.WriteFile(staleBackupPath, []byte("stale"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ev := &ExactVersion{
+		Product:          product.Terraform,
+		Version:          version.Must(version.NewVersion("0.14.11")),
+		ArmoredPublicKey: getTestPubKey(t),
+		ApiBaseURL:       srv.URL,
+		InstallDir:       installDir,
+	}
+	ev.SetLogger(testutil.TestLogger())
+
+	ctx := context.Background()
+	_, err = ev.Install(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(existingPath + rjson.BackupSuffix + ".1"); err != nil {
+		t.Fatalf("expected numbered backup of overwritten file: %s", err)
+	}
+
+	err = ev.Remove(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(existingPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "existing" {
+		t.Fatalf("expected overwritten file to be restored, got %q", b)
+	}
+	b, err = os.ReadFile(staleBackupPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "stale" {
+		t.Fatalf("expected existing backup to be kept, got %q", b)
+	}
+}
+
+func TestExactVersion_verifyVersion(t *testing.T) {
+	mockApiRoot := filepath.Join("testdata", "mock_api_tf_0_14_with_prereleases")
+	srv := testutil.NewTestServer(t, mockApiRoot)
+
+	reportedVersion := func(v string) func(context.Context, string) (*version.Version, error) {
+		return func(context.Context, string) (*version.Version, error) {
+			return version.NewVersion(v)
+		}
+	}
+
+	testCases := map[string]struct {
+		getVersion      func(context.Context, string) (*version.Version, error)
+		expectedVersion string
+	}{
+		"executed": {
+			getVersion: product.Terraform.GetVersion,
+		},
+		"different-version": {
+			getVersion:      reportedVersion("0.14.10"),
+			expectedVersion: "0.14.10",
+		},
+		"different-metadata": {
+			getVersion:      reportedVersion("0.14.11+ent"),
+			expectedVersion: "0.14.11+ent",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := product.Terraform
+			p.GetVersion = testCase.getVersion
+
+			installDir := t.TempDir()
+			existingPath := filepath.Join(installDir, "terraform")
+			err := os.WriteFile(existingPath, []byte("existing"), 0o755)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ev := &ExactVersion{
+				Product:          p,
+				Version:          version.Must(version.NewVersion("0.14.11")),
+				ArmoredPublicKey: getTestPubKey(t),
+				ApiBaseURL:       srv.URL,
+				InstallDir:       installDir,
+				VerifyVersion:    true,
+			}
+			ev.SetLogger(testutil.TestLogger())
+			err = ev.Validate()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			t.Cleanup(func() { ev.Remove(ctx) })
+			_, err = ev.Install(ctx)
+			if testCase.expectedVersion == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			var mismatchErr *hcerrors.VersionMismatchError
+			if !errors.As(err, &mismatchErr) {
+				t.Fatalf("expected version mismatch error, got: %v", err)
+			}
+			if mismatchErr.Actual.String() != testCase.expectedVersion {
+				t.Fatalf("expected reported version %s, got %s",
+					testCase.expectedVersion, mismatchErr.Actual)
+			}
+
+			// install is rolled back
+			b, err := os.ReadFile(existingPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != "existing" {
+				t.Fatalf("expected overwritten file to be restored, got %d bytes", len(b))
+			}
+			entries, err := os.ReadDir(installDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 1 {
+				t.Fatalf("expected only the restored file to be left, found %d entries", len(entries))
+			}
+			plan, err := ev.PlanRemove(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(plan.Remove) != 0 || len(plan.Restore) != 0 {
+				t.Fatalf("expected nothing left to remove, got %#v", plan)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	mockApiRoot := filepath.Join("testdata", "mock_api_tf_0_14_with_prereleases")
+	apiBaseURL := testutil.NewTestServer(t, mockApiRoot).URL
+	installDir := t.TempDir()
+
+	testCases := map[string]src.Resolvable{
+		"ExactVersion": &ExactVersion{
+			Product:    product.Terraform,
+			Version:    version.Must(version.NewVersion("0.14.11")),
+			InstallDir: installDir,
+			ApiBaseURL: apiBaseURL,
+			IndexCache: &rjson.IndexCache{Dir: filepath.Join(installDir, "cache")},
+		},
+		"LatestVersion": &LatestVersion{
+			Product:    product.Terraform,
+			InstallDir: installDir,
+			ApiBaseURL: apiBaseURL,
+			IndexCache: &rjson.IndexCache{Dir: filepath.Join(installDir, "cache")},
+		},
+	}
+
+	for name, source := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r, err := source.Resolve(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expectedVersion := version.Must(version.NewVersion("0.14.11"))
+			if !r.Version.Equal(expectedVersion) {
+				t.Fatalf("unexpected version: %s", r.Version)
+			}
+			expectedURL := apiBaseURL + "/terraform/0.14.11/terraform_0.14.11_linux_amd64.zip"
+			if r.ArchiveURL != expectedURL {
+				t.Fatalf("unexpected archive URL: %q, expected %q", r.ArchiveURL, expectedURL)
+			}
+			expectedPath := filepath.Join(installDir, "terraform")
+			if r.ExecPath != expectedPath {
+				t.Fatalf("unexpected path: %q, expected %q", r.ExecPath, expectedPath)
+			}
+
+			entries, err := os.ReadDir(installDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) > 0 {
+				t.Fatalf("expected no changes to install dir, found %d entries", len(entries))
+			}
+		})
+	}
+}
+
+func TestResolve_targetBinaryName(t *testing.T) {
+	mockApiRoot := filepath.Join("testdata", "mock_api_tf_0_14_with_prereleases")
+	apiBaseURL := testutil.NewTestServer(t, mockApiRoot).URL
+	installDir := t.TempDir()
+
+	p := product.Terraform
+	p.TargetBinaryName = func(t product.Target) string {
+		return fmt.Sprintf("terraform_v%s_%s_%s", t.Version, t.OS, t.Arch)
+	}
+
+	testCases := map[string]src.Resolvable{
+		"ExactVersion": &ExactVersion{
+			Product:    p,
+			Version:    version.Must(version.NewVersion("0.14.11")),
+			InstallDir: installDir,
+			ApiBaseURL: apiBaseURL,
+		},
+		"LatestVersion": &LatestVersion{
+			Product:    p,
+			InstallDir: installDir,
+			ApiBaseURL: apiBaseURL,
+		},
+	}
+
+	for name, source := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r, err := source.Resolve(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expectedPath := filepath.Join(installDir, "terraform_v0.14.11_"+runtime.GOOS+"_"+runtime.GOARCH)
+			if r.ExecPath != expectedPath {
+				t.Fatalf("unexpected path: %q, expected %q", r.ExecPath, expectedPath)
+			}
+		})
+	}
+}
+
 func BenchmarkExactVersion(b *testing.B) {
 	mockApiRoot := filepath.Join("testdata", "mock_api_tf_0_14_with_prereleases")
 
@@ -0,0 +1,10 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package releases
+
+import "github.com/hashicorp/hc-install/src"
+
+// SigstoreOptions represents options for verification of a Sigstore
+// bundle published alongside the checksums, in addition to the PGP signature
+type SigstoreOptions = src.SigstoreOptions
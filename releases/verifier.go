@@ -0,0 +1,324 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package releases
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/internal/pubkey"
+	"github.com/hashicorp/hc-install/internal/release"
+	"github.com/hashicorp/hc-install/internal/tempdir"
+	"github.com/hashicorp/hc-install/internal/validators"
+	"github.com/hashicorp/hc-install/product"
+	rjson "github.com/hashicorp/hc-install/releasesjson"
+)
+
+// ErrNoMatchingRelease is returned when a verified file doesn't match
+// any file published as part of the signed release
+var ErrNoMatchingRelease = errors.New("file does not match any file of the signed release")
+
+// Verifier checks whether a local file matches an official release
+// by comparing it against the signed SHA256SUMS, without installing anything.
+type Verifier struct {
+	Product product.Product
+
+	// Version represents the release version to verify against,
+	// required for binaries, optional for archives named
+	// as on the releases site (e.g. terraform_1.5.0_linux_amd64.zip)
+	Version *version.Version
+
+	// Enterprise indicates verification against enterprise version
+	// (leave nil for Community editions)
+	Enterprise *EnterpriseOptions
+
+	// OS and Arch represent the platform of the verified binary,
+	// defaulting to the platform hc-install is running on.
+	// These are ignored when verifying archives.
+	OS   string
+	Arch string
+
+	Timeout time.Duration
+
+	// ArmoredPublicKey is a public PGP key in ASCII/armor format to use
+	// instead of built-in pubkey to verify signature of checksums
+	ArmoredPublicKey string
+
+	// AdditionalArmoredPublicKeys are public PGP keys in ASCII/armor format
+	// which are trusted alongside the built-in pubkey (or ArmoredPublicKey)
+	AdditionalArmoredPublicKeys []string
+
+	// Sigstore enables verification of Sigstore bundle of the checksums
+	// in addition to the PGP signature (leave nil to verify PGP only)
+	Sigstore *SigstoreOptions
+
+	// ApiBaseURL is an optional field that specifies a custom URL to obtain
+	// the checksums (and archives) from instead of the default site.
+	ApiBaseURL string
+
+	// HTTPClient represents an optional client used for all requests,
+	// e.g. to configure proxy or TLS settings of a mirror
+	HTTPClient *http.Client
+
+	logger *log.Logger
+}
+
+// Verification describes the release file a verified file was matched with
+type Verification struct {
+	// Version is the release version the file was verified against
+	Version *version.Version
+
+	// Filename is the name of the release archive which matched the file
+	Filename string
+
+	// SHA256 is the hex-encoded checksum of the verified file
+	SHA256 string
+
+	// SigningKeyID and SigningKeyFingerprint identify
+	// the PGP key which signed the checksums
+	SigningKeyID          string
+	SigningKeyFingerprint string
+}
+
+func (v *Verifier) SetLogger(logger *log.Logger) {
+	v.logger = logger
+}
+
+func (v *Verifier) log() *log.Logger {
+	if v.logger == nil {
+		return discardLogger
+	}
+	return v.logger
+}
+
+func (v *Verifier) Validate() error {
+	if !validators.IsProductNameValid(v.Product.Name) {
+		return fmt.Errorf("invalid product name: %q", v.Product.Name)
+	}
+	return release.ValidateSigstoreOptions(v.Sigstore)
+}
+
+// VerifyArchive verifies that the ZIP archive at the given path
+// matches any archive listed in the signed checksums of the release
+func (v *Verifier) VerifyArchive(ctx context.Context, archivePath string) (*Verification, error) {
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+
+	ver := v.Version
+	if ver == nil {
+		var err error
+		ver, err = versionFromArchiveName(v.Product.Name, filepath.Base(archivePath))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancelFunc := v.withTimeout(ctx)
+	defer cancelFunc()
+
+	sum, err := fileChecksum(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	v.log().Printf("calculated checksum of %s: %x", archivePath, sum)
+
+	pv, cd, checksums, err := v.verifiedChecksums(ctx, ver)
+	if err != nil {
+		return nil, err
+	}
+
+	for filename, expectedSum := range checksums {
+		if bytes.Equal(sum, expectedSum) {
+			v.log().Printf("checksum of %s matches %q", archivePath, filename)
+			return &Verification{
+				Version:               pv.Version,
+				Filename:              filename,
+				SHA256:                sum.String(),
+				SigningKeyID:          cd.SigningKeyID(),
+				SigningKeyFingerprint: cd.SigningKeyFingerprint(),
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s: %w", archivePath, ErrNoMatchingRelease)
+}
+
+// VerifyBinary verifies that the unpacked binary at the given path
+// matches the content of the release archive for the configured
+// Version and platform, after verifying the archive checksum
+func (v *Verifier) VerifyBinary(ctx context.Context, binPath string) (*Verification, error) {
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	if v.Version == nil {
+		return nil, fmt.Errorf("unknown version")
+	}
+
+	ctx, cancelFunc := v.withTimeout(ctx)
+	defer cancelFunc()
+
+	sum, err := fileChecksum(binPath)
+	if err != nil {
+		return nil, err
+	}
+	v.log().Printf("calculated checksum of %s: %x", binPath, sum)
+
+	pv, err := v.productVersion(ctx, v.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := tempdir.MkdirTemp(fmt.Sprintf("hc-install-verify-%s", v.Product.Name))
+	if err != nil {
+		return nil, err
+	}
+	defer tempdir.RemoveAll(tmpDir)
+
+	d := &rjson.Downloader{
+		HTTPClient:                  v.HTTPClient,
+		Logger:                      v.log(),
+		VerifyChecksum:              true,
+		ArmoredPublicKey:            v.armoredPublicKey(),
+		BaseURL:                     v.baseURL(),
+		OS:                          v.OS,
+		Arch:                        v.Arch,
+		AdditionalArmoredPublicKeys: v.AdditionalArmoredPublicKeys,
+		ChecksumVerifiers:           v.checksumVerifiers(),
+	}
+	up, err := d.DownloadAndUnpack(ctx, pv, tmpDir, "")
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || entry.Name() == tempdir.MarkerFileName {
+			continue
+		}
+		unpackedSum, err := fileChecksum(filepath.Join(tmpDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(sum, unpackedSum) {
+			v.log().Printf("checksum of %s matches %q from the release archive", binPath, entry.Name())
+			return &Verification{
+				Version:               pv.Version,
+				Filename:              entry.Name(),
+				SHA256:                sum.String(),
+				SigningKeyID:          up.SigningKeyID,
+				SigningKeyFingerprint: up.SigningKeyFingerprint,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s: %w", binPath, ErrNoMatchingRelease)
+}
+
+func (v *Verifier) verifiedChecksums(ctx context.Context, ver *version.Version) (*rjson.ProductVersion, *rjson.ChecksumDownloader, rjson.ChecksumFileMap, error) {
+	pv, err := v.productVersion(ctx, ver)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	cd := &rjson.ChecksumDownloader{
+		HTTPClient:                  v.HTTPClient,
+		ProductVersion:              pv,
+		Logger:                      v.log(),
+		ArmoredPublicKey:            v.armoredPublicKey(),
+		BaseURL:                     v.baseURL(),
+		AdditionalArmoredPublicKeys: v.AdditionalArmoredPublicKeys,
+		Verifiers:                   v.checksumVerifiers(),
+	}
+	checksums, err := cd.DownloadAndVerifyChecksums(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return pv, cd, checksums, nil
+}
+
+func (v *Verifier) productVersion(ctx context.Context, ver *version.Version) (*rjson.ProductVersion, error) {
+	rels := rjson.NewReleases()
+	rels.BaseURL = v.baseURL()
+	rels.HTTPClient = v.HTTPClient
+	rels.SetLogger(v.log())
+
+	if v.Enterprise != nil {
+		ver = versionWithMetadata(ver, enterpriseVersionMetadata(v.Enterprise))
+	}
+
+	return rels.GetProductVersion(ctx, v.Product.Name, ver)
+}
+
+func (v *Verifier) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := defaultInstallTimeout
+	if v.Timeout > 0 {
+		timeout = v.Timeout
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func (v *Verifier) baseURL() string {
+	if v.ApiBaseURL != "" {
+		return v.ApiBaseURL
+	}
+	return rjson.NewReleases().BaseURL
+}
+
+func (v *Verifier) checksumVerifiers() []rjson.ChecksumVerifier {
+	return release.ChecksumVerifiers(v.Sigstore, v.armoredPublicKey(), v.AdditionalArmoredPublicKeys, v.log())
+}
+
+func (v *Verifier) armoredPublicKey() string {
+	if v.ArmoredPublicKey != "" {
+		return v.ArmoredPublicKey
+	}
+	return pubkey.DefaultPublicKey
+}
+
+// versionFromArchiveName parses version from archive filename
+// following the releases site convention, i.e. <product>_<version>_<os>_<arch>.zip
+func versionFromArchiveName(productName, filename string) (*version.Version, error) {
+	trimmed := strings.TrimPrefix(filename, productName+"_")
+	parts := strings.Split(trimmed, "_")
+	if trimmed == filename || len(parts) != 3 {
+		return nil, fmt.Errorf("unable to determine version from archive name %q, please provide version", filename)
+	}
+
+	v, err := version.NewVersion(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine version from archive name %q: %w", filename, err)
+	}
+	return v, nil
+}
+
+func fileChecksum(path string) (rjson.HashSum, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate checksum of %s: %w", path, err)
+	}
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,143 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package releases
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/internal/testutil"
+	"github.com/hashicorp/hc-install/product"
+)
+
+func TestVerifier_VerifyArchive(t *testing.T) {
+	mockApiRoot := filepath.Join("testdata", "mock_api_tf_0_14_with_prereleases")
+	archivePath := filepath.Join("testdata", "mock_terraform_builds", "0.14.11", "terraform_0.14.11_linux_amd64.zip")
+
+	v := &Verifier{
+		Product:          product.Terraform,
+		ArmoredPublicKey: getTestPubKey(t),
+		ApiBaseURL:       testutil.NewTestServer(t, mockApiRoot).URL,
+	}
+	v.SetLogger(testutil.TestLogger())
+
+	verification, err := v.VerifyArchive(context.Background(), archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if verification.Filename != "terraform_0.14.11_linux_amd64.zip" {
+		t.Fatalf("unexpected matched filename: %q", verification.Filename)
+	}
+	if verification.Version.String() != "0.14.11" {
+		t.Fatalf("unexpected version: %s", verification.Version)
+	}
+	if verification.SigningKeyID == "" {
+		t.Fatal("expected signing key ID to be reported")
+	}
+}
+
+func TestVerifier_VerifyArchive_mismatch(t *testing.T) {
+	mockApiRoot := filepath.Join("testdata", "mock_api_tf_0_14_with_prereleases")
+	archivePath := filepath.Join(t.TempDir(), "terraform_0.14.11_linux_amd64.zip")
+	err := os.WriteFile(archivePath, []byte("tampered"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := &Verifier{
+		Product:          product.Terraform,
+		ArmoredPublicKey: getTestPubKey(t),
+		ApiBaseURL:       testutil.NewTestServer(t, mockApiRoot).URL,
+	}
+	v.SetLogger(testutil.TestLogger())
+
+	_, err = v.VerifyArchive(context.Background(), archivePath)
+	if !errors.Is(err, ErrNoMatchingRelease) {
+		t.Fatalf("expected %q, got: %v", ErrNoMatchingRelease, err)
+	}
+}
+
+func TestVerifier_VerifyBinary(t *testing.T) {
+	mockApiRoot := filepath.Join("testdata", "mock_api_tf_0_14_with_prereleases")
+	archivePath := filepath.Join("testdata", "mock_terraform_builds", "0.14.11", "terraform_0.14.11_linux_amd64.zip")
+	binPath := unzipTestFile(t, archivePath, "terraform")
+
+	v := &Verifier{
+		Product:          product.Terraform,
+		Version:          version.Must(version.NewVersion("0.14.11")),
+		OS:               "linux",
+		Arch:             "amd64",
+		ArmoredPublicKey: getTestPubKey(t),
+		ApiBaseURL:       testutil.NewTestServer(t, mockApiRoot).URL,
+	}
+	v.SetLogger(testutil.TestLogger())
+
+	verification, err := v.VerifyBinary(context.Background(), binPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verification.Filename != "terraform" {
+		t.Fatalf("unexpected matched filename: %q", verification.Filename)
+	}
+}
+
+func TestVersionFromArchiveName(t *testing.T) {
+	testCases := []struct {
+		product         string
+		filename        string
+		expectedVersion string
+	}{
+		{"terraform", "terraform_1.5.0_linux_amd64.zip", "1.5.0"},
+		{"terraform", "terraform_0.15.0-rc2_linux_386.zip", "0.15.0-rc2"},
+		{"vault", "vault_1.9.8+ent_darwin_arm64.zip", "1.9.8+ent"},
+	}
+	for _, tc := range testCases {
+		v, err := versionFromArchiveName(tc.product, tc.filename)
+		if err != nil {
+			t.Fatalf("%s: %s", tc.filename, err)
+		}
+		if v.Original() != tc.expectedVersion {
+			t.Fatalf("%s: expected %q, got %q", tc.filename, tc.expectedVersion, v.Original())
+		}
+	}
+
+	_, err := versionFromArchiveName("terraform", "renamed.zip")
+	if err == nil {
+		t.Fatal("expected error for unrecognized archive name")
+	}
+}
+
+func unzipTestFile(t *testing.T, archivePath, filename string) string {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	f, err := r.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	dstPath := filepath.Join(t.TempDir(), filename)
+	dst, err := os.Create(dstPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+
+	_, err = io.Copy(dst, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dstPath
+}
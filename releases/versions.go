@@ -6,13 +6,18 @@ package releases
 import (
 	"context"
 	"fmt"
+	"log"
+	"net/http"
 	"sort"
 	"time"
 
 	"github.com/hashicorp/go-version"
-	rjson "github.com/hashicorp/hc-install/internal/releasesjson"
+	"github.com/hashicorp/hc-install/internal/release"
 	"github.com/hashicorp/hc-install/internal/validators"
+	"github.com/hashicorp/hc-install/policy"
 	"github.com/hashicorp/hc-install/product"
+	"github.com/hashicorp/hc-install/registry"
+	rjson "github.com/hashicorp/hc-install/releasesjson"
 	"github.com/hashicorp/hc-install/src"
 )
 
@@ -25,8 +30,42 @@ type Versions struct {
 
 	ListTimeout time.Duration
 
+	// ApiBaseURL is an optional field that specifies a custom URL to list
+	// versions from and to install any listed version from, instead of
+	// the default site. The directory structure of the custom URL must
+	// match the HashiCorp releases site (including the index.json files).
+	ApiBaseURL string
+
+	// HTTPClient represents an optional client used for listing and installation,
+	// e.g. to configure proxy or TLS settings of a mirror
+	HTTPClient *http.Client
+
+	// IndexCache represents an optional on-disk cache of index.json files
+	// which are revalidated using conditional requests. It is also used
+	// by installation of any listed version.
+	IndexCache *rjson.IndexCache
+
 	// Install represents configuration for installation of any listed version
 	Install InstallationOptions
+
+	// Policy represents an optional policy which has to allow a version
+	// for it to be listed. It is also set on any listed version.
+	Policy policy.Policy
+
+	logger *log.Logger
+}
+
+// SetLogger sets the logger used for listing, which is also
+// set on any listed version
+func (v *Versions) SetLogger(logger *log.Logger) {
+	v.logger = logger
+}
+
+func (v *Versions) log() *log.Logger {
+	if v.logger == nil {
+		return discardLogger
+	}
+	return v.logger
 }
 
 type InstallationOptions struct {
@@ -40,6 +79,30 @@ type InstallationOptions struct {
 	// instead of built-in pubkey to verify signature of downloaded checksums
 	// during installation
 	ArmoredPublicKey string
+
+	// AdditionalArmoredPublicKeys are public PGP keys in ASCII/armor format
+	// which are trusted alongside the built-in pubkey (or ArmoredPublicKey)
+	AdditionalArmoredPublicKeys []string
+
+	// Sigstore enables verification of Sigstore bundle of the checksums
+	// in addition to the PGP signature (leave nil to verify PGP only)
+	Sigstore *SigstoreOptions
+
+	// WriteReceipt indicates that an install receipt
+	// should be written next to the installed binary
+	WriteReceipt bool
+
+	// VerifyVersion indicates that the installed binary has to report
+	// the installed version, see ExactVersion.VerifyVersion
+	VerifyVersion bool
+
+	// PreferBuildInfo indicates that VerifyVersion reads the version
+	// without executing the binary, see ExactVersion.PreferBuildInfo
+	PreferBuildInfo bool
+
+	// Registry represents an optional on-disk registry
+	// where files created by the install are recorded
+	Registry *registry.Registry
 }
 
 func (v *Versions) List(ctx context.Context) ([]src.Source, error) {
@@ -51,6 +114,10 @@ func (v *Versions) List(ctx context.Context) ([]src.Source, error) {
 		return nil, err
 	}
 
+	if err := release.ValidateSigstoreOptions(v.Install.Sigstore); err != nil {
+		return nil, err
+	}
+
 	timeout := defaultListTimeout
 	if v.ListTimeout > 0 {
 		timeout = v.ListTimeout
@@ -59,6 +126,12 @@ func (v *Versions) List(ctx context.Context) ([]src.Source, error) {
 	defer cancelFunc()
 
 	r := rjson.NewReleases()
+	if v.ApiBaseURL != "" {
+		r.BaseURL = v.ApiBaseURL
+	}
+	r.Cache = v.IndexCache
+	r.HTTPClient = v.HTTPClient
+	r.SetLogger(v.log())
 	pvs, err := r.ListProductVersions(ctx, v.Product.Name)
 	if err != nil {
 		return nil, err
@@ -81,6 +154,12 @@ func (v *Versions) List(ctx context.Context) ([]src.Source, error) {
 			continue
 		}
 
+		err := policy.Check(ctx, v.Policy, policyCandidate(v.Product, pv, v.Install.SkipChecksumVerification))
+		if err != nil {
+			v.log().Printf("skipping version %s: %s", pv.Version, err)
+			continue
+		}
+
 		ev := &ExactVersion{
 			Product:    v.Product,
 			Version:    pv.Version,
@@ -88,8 +167,19 @@ func (v *Versions) List(ctx context.Context) ([]src.Source, error) {
 			Timeout:    v.Install.Timeout,
 			LicenseDir: v.Install.LicenseDir,
 
-			ArmoredPublicKey:         v.Install.ArmoredPublicKey,
-			SkipChecksumVerification: v.Install.SkipChecksumVerification,
+			ArmoredPublicKey:            v.Install.ArmoredPublicKey,
+			AdditionalArmoredPublicKeys: v.Install.AdditionalArmoredPublicKeys,
+			Sigstore:                    v.Install.Sigstore,
+			IndexCache:                  v.IndexCache,
+			ApiBaseURL:                  v.ApiBaseURL,
+			HTTPClient:                  v.HTTPClient,
+			SkipChecksumVerification:    v.Install.SkipChecksumVerification,
+			Policy:                      v.Policy,
+
+			WriteReceipt:    v.Install.WriteReceipt,
+			VerifyVersion:   v.Install.VerifyVersion,
+			PreferBuildInfo: v.Install.PreferBuildInfo,
+			Registry:        v.Install.Registry,
 		}
 
 		if v.Enterprise != nil {
@@ -98,6 +188,10 @@ func (v *Versions) List(ctx context.Context) ([]src.Source, error) {
 			}
 		}
 
+		if v.logger != nil {
+			ev.SetLogger(v.logger)
+		}
+
 		installables = append(installables, ev)
 	}
 
@@ -5,12 +5,16 @@ package releases
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hc-install/internal/testutil"
+	"github.com/hashicorp/hc-install/policy"
 	"github.com/hashicorp/hc-install/product"
+	"github.com/hashicorp/hc-install/registry"
 	"github.com/hashicorp/hc-install/src"
 )
 
@@ -101,6 +105,52 @@ func TestVersions_List_enterprise(t *testing.T) {
 	}
 }
 
+func TestVersions_List_apiBaseURL(t *testing.T) {
+	mockApiRoot := filepath.Join("testdata", "mock_api_tf_0_14_with_prereleases")
+	srv := testutil.NewTestServer(t, mockApiRoot)
+
+	versions := &Versions{
+		Product:     product.Terraform,
+		Constraints: version.MustConstraints(version.NewConstraint(">= 0.14.0")),
+		ApiBaseURL:  srv.URL,
+		HTTPClient:  srv.Client(),
+		Install: InstallationOptions{
+			ArmoredPublicKey: getTestPubKey(t),
+		},
+	}
+	versions.SetLogger(testutil.TestLogger())
+
+	ctx := context.Background()
+	sources, err := versions.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedVersions := []string{"0.14.11"}
+	if diff := cmp.Diff(expectedVersions, sourcesToRawVersions(sources)); diff != "" {
+		t.Fatalf("unexpected versions: %s", diff)
+	}
+
+	ev := sources[0].(*ExactVersion)
+	if ev.ApiBaseURL != srv.URL {
+		t.Fatalf("unexpected ApiBaseURL of listed version: %q", ev.ApiBaseURL)
+	}
+
+	execPath, err := ev.Install(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ev.Remove(ctx) })
+
+	v, err := product.Terraform.GetVersion(ctx, execPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.String() != "0.14.11" {
+		t.Fatalf("unexpected installed version: %s", v)
+	}
+}
+
 func sourcesToRawVersions(srcs []src.Source) []string {
 	rawVersions := make([]string, len(srcs))
 
@@ -111,3 +161,125 @@ func sourcesToRawVersions(srcs []src.Source) []string {
 
 	return rawVersions
 }
+
+func TestVersions_List_policy(t *testing.T) {
+	mockApiRoot := filepath.Join("testdata", "mock_api_tf_0_14_with_prereleases")
+	srv := testutil.NewTestServer(t, mockApiRoot)
+
+	versions := &Versions{
+		Product:     product.Terraform,
+		Constraints: version.MustConstraints(version.NewConstraint(">= 0.14.0")),
+		ApiBaseURL:  srv.URL,
+		Install: InstallationOptions{
+			SkipChecksumVerification: true,
+		},
+		Policy: &policy.RequireSignature{},
+	}
+	versions.SetLogger(testutil.TestLogger())
+
+	sources, err := versions.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sources) != 0 {
+		t.Fatalf("expected no versions to be allowed, given: %q", sourcesToRawVersions(sources))
+	}
+
+	versions.Install.SkipChecksumVerification = false
+	sources, err = versions.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sources) != 1 {
+		t.Fatalf("expected 1 version to be allowed, given: %q", sourcesToRawVersions(sources))
+	}
+	if sources[0].(*ExactVersion).Policy == nil {
+		t.Fatal("expected policy to be set on listed version")
+	}
+}
+
+func TestVersions_List_installationOptions(t *testing.T) {
+	mockApiRoot := filepath.Join("testdata", "mock_api_tf_0_14_with_prereleases")
+	srv := testutil.NewTestServer(t, mockApiRoot)
+
+	reg := &registry.Registry{Dir: t.TempDir()}
+	sigstoreOpts := &SigstoreOptions{
+		TrustedRoot:           []byte("{}"),
+		CertificateIdentity:   "https://example.com/release.yml",
+		CertificateOIDCIssuer: "https://example.com",
+	}
+
+	testCases := map[string]struct {
+		opts  InstallationOptions
+		check func(ev *ExactVersion) bool
+	}{
+		"Timeout": {
+			opts:  InstallationOptions{Timeout: time.Minute},
+			check: func(ev *ExactVersion) bool { return ev.Timeout == time.Minute },
+		},
+		"Dir": {
+			opts:  InstallationOptions{Dir: "/some/dir"},
+			check: func(ev *ExactVersion) bool { return ev.InstallDir == "/some/dir" },
+		},
+		"LicenseDir": {
+			opts:  InstallationOptions{LicenseDir: "/some/license/dir"},
+			check: func(ev *ExactVersion) bool { return ev.LicenseDir == "/some/license/dir" },
+		},
+		"SkipChecksumVerification": {
+			opts:  InstallationOptions{SkipChecksumVerification: true},
+			check: func(ev *ExactVersion) bool { return ev.SkipChecksumVerification },
+		},
+		"ArmoredPublicKey": {
+			opts:  InstallationOptions{ArmoredPublicKey: "key"},
+			check: func(ev *ExactVersion) bool { return ev.ArmoredPublicKey == "key" },
+		},
+		"AdditionalArmoredPublicKeys": {
+			opts: InstallationOptions{AdditionalArmoredPublicKeys: []string{"key"}},
+			check: func(ev *ExactVersion) bool {
+				return cmp.Equal(ev.AdditionalArmoredPublicKeys, []string{"key"})
+			},
+		},
+		"Sigstore": {
+			opts:  InstallationOptions{Sigstore: sigstoreOpts},
+			check: func(ev *ExactVersion) bool { return ev.Sigstore == sigstoreOpts },
+		},
+		"WriteReceipt": {
+			opts:  InstallationOptions{WriteReceipt: true},
+			check: func(ev *ExactVersion) bool { return ev.WriteReceipt },
+		},
+		"VerifyVersion": {
+			opts:  InstallationOptions{VerifyVersion: true},
+			check: func(ev *ExactVersion) bool { return ev.VerifyVersion },
+		},
+		"PreferBuildInfo": {
+			opts:  InstallationOptions{PreferBuildInfo: true},
+			check: func(ev *ExactVersion) bool { return ev.PreferBuildInfo },
+		},
+		"Registry": {
+			opts:  InstallationOptions{Registry: reg},
+			check: func(ev *ExactVersion) bool { return ev.Registry == reg },
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			versions := &Versions{
+				Product:     product.Terraform,
+				Constraints: version.MustConstraints(version.NewConstraint(">= 0.14.0")),
+				ApiBaseURL:  srv.URL,
+				Install:     testCase.opts,
+			}
+
+			sources, err := versions.List(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(sources) != 1 {
+				t.Fatalf("expected 1 version, given: %q", sourcesToRawVersions(sources))
+			}
+			if !testCase.check(sources[0].(*ExactVersion)) {
+				t.Fatalf("%s was not set on listed version", name)
+			}
+		})
+	}
+}
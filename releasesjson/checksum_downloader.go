@@ -0,0 +1,168 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package releasesjson
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/hc-install/internal/pubkey"
+)
+
+type ChecksumDownloader struct {
+	ProductVersion *ProductVersion
+	Logger         *log.Logger
+
+	// ArmoredPublicKey represents the key trusted to sign the checksums,
+	// defaulting to the public key of HashiCorp
+	ArmoredPublicKey string
+
+	// AdditionalArmoredPublicKeys represents keys which are trusted
+	// alongside ArmoredPublicKey, e.g. during key rotation
+	AdditionalArmoredPublicKeys []string
+
+	// Verifiers represents verifiers of the checksums which all
+	// have to succeed. Defaults to verification of PGP signature
+	// using ArmoredPublicKey and AdditionalArmoredPublicKeys.
+	Verifiers []ChecksumVerifier
+
+	BaseURL string
+
+	// HTTPClient represents the client used for all requests,
+	// defaulting to a client which retries failed requests
+	HTTPClient *http.Client
+
+	verifications []*ChecksumVerification
+}
+
+type ChecksumFileMap map[string]HashSum
+
+type HashSum []byte
+
+func (hs HashSum) Size() int {
+	return len(hs)
+}
+
+func (hs HashSum) String() string {
+	return hex.EncodeToString(hs)
+}
+
+func HashSumFromHexDigest(hexDigest string) (HashSum, error) {
+	sumBytes, err := hex.DecodeString(hexDigest)
+	if err != nil {
+		return nil, err
+	}
+	return HashSum(sumBytes), nil
+}
+
+func (cd *ChecksumDownloader) DownloadAndVerifyChecksums(ctx context.Context) (ChecksumFileMap, error) {
+	rf := NewReleaseFiles(cd.ProductVersion, cd.BaseURL)
+	rf.HTTPClient = cd.HTTPClient
+	rf.SetLogger(cd.Logger)
+
+	sumsBody, err := rf.Download(ctx, cd.ProductVersion.SHASUMS)
+	if err != nil {
+		return nil, err
+	}
+	defer sumsBody.Close()
+
+	shaSums, err := io.ReadAll(sumsBody)
+	if err != nil {
+		return nil, err
+	}
+
+	cd.verifications = make([]*ChecksumVerification, 0)
+	for _, verifier := range cd.verifiers() {
+		verification, err := verifier.VerifyChecksums(ctx, rf, shaSums)
+		if err != nil {
+			return nil, err
+		}
+		cd.verifications = append(cd.verifications, verification)
+	}
+
+	return fileMapFromChecksums(shaSums)
+}
+
+func (cd *ChecksumDownloader) verifiers() []ChecksumVerifier {
+	if len(cd.Verifiers) > 0 {
+		return cd.Verifiers
+	}
+
+	armoredKey := cd.ArmoredPublicKey
+	if armoredKey == "" {
+		armoredKey = pubkey.DefaultPublicKey
+	}
+	armoredKeys := append([]string{armoredKey}, cd.AdditionalArmoredPublicKeys...)
+
+	return []ChecksumVerifier{
+		&PGPVerifier{
+			ArmoredPublicKeys: armoredKeys,
+			Logger:            cd.Logger,
+		},
+	}
+}
+
+// Verifications returns results of all verifications
+// of the checksums which were last downloaded
+func (cd *ChecksumDownloader) Verifications() []*ChecksumVerification {
+	return cd.verifications
+}
+
+func fileMapFromChecksums(checksums []byte) (ChecksumFileMap, error) {
+	csMap := make(ChecksumFileMap, 0)
+
+	lines := strings.Split(string(checksums), "\n")
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		parts := strings.Fields(line)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("unexpected checksum line format: %q", line)
+		}
+
+		h, err := HashSumFromHexDigest(parts[0])
+		if err != nil {
+			return nil, err
+		}
+
+		if h.Size() != sha256.Size {
+			return nil, fmt.Errorf("unexpected sha256 format (len: %d, expected: %d)",
+				h.Size(), sha256.Size)
+		}
+
+		csMap[parts[1]] = h
+	}
+	return csMap, nil
+}
+
+// SigningKeyID returns ID of the PGP key which produced valid signature
+// of the checksums, or empty string if no signature was verified yet
+func (cd *ChecksumDownloader) SigningKeyID() string {
+	for _, v := range cd.verifications {
+		if v.KeyID != "" {
+			return v.KeyID
+		}
+	}
+	return ""
+}
+
+// SigningKeyFingerprint returns hex-encoded fingerprint of the PGP key
+// which produced valid signature of the checksums, or empty string
+// if no signature was verified yet
+func (cd *ChecksumDownloader) SigningKeyFingerprint() string {
+	for _, v := range cd.verifications {
+		if v.KeyFingerprint != "" {
+			return v.KeyFingerprint
+		}
+	}
+	return ""
+}
@@ -0,0 +1,106 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package releasesjson
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/hc-install/internal/httpclient"
+)
+
+// ChecksumVerifier verifies authenticity of the checksums (SHA256SUMS)
+// of a product version, e.g. by checking a detached signature
+// which is published alongside them
+type ChecksumVerifier interface {
+	VerifyChecksums(ctx context.Context, rf *ReleaseFiles, checksums []byte) (*ChecksumVerification, error)
+}
+
+// ChecksumVerification describes who vouched for the checksums
+type ChecksumVerification struct {
+	// Method identifies the verifier, e.g. "pgp" or "sigstore"
+	Method string
+
+	// KeyID and KeyFingerprint identify the PGP key
+	// (or subkey) which signed the checksums
+	KeyID          string
+	KeyFingerprint string
+
+	// Identity and Issuer identify the subject
+	// of the certificate which signed the checksums
+	Identity string
+	Issuer   string
+}
+
+// ReleaseFiles provides access to any files published as part
+// of a product version, such as checksums and their signatures
+type ReleaseFiles struct {
+	ProductVersion *ProductVersion
+	BaseURL        string
+
+	// HTTPClient represents the client used for all requests,
+	// defaulting to a client which retries failed requests
+	HTTPClient *http.Client
+
+	logger *log.Logger
+}
+
+func NewReleaseFiles(pv *ProductVersion, baseURL string) *ReleaseFiles {
+	return &ReleaseFiles{
+		ProductVersion: pv,
+		BaseURL:        baseURL,
+	}
+}
+
+func (rf *ReleaseFiles) SetLogger(logger *log.Logger) {
+	rf.logger = logger
+}
+
+func (rf *ReleaseFiles) log() *log.Logger {
+	if rf.logger == nil {
+		return discardLogger
+	}
+	return rf.logger
+}
+
+// URL returns URL of the named release file
+func (rf *ReleaseFiles) URL(filename string) string {
+	baseURL := rf.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	return fmt.Sprintf("%s/%s/%s/%s", baseURL,
+		url.PathEscape(rf.ProductVersion.Name),
+		url.PathEscape(rf.ProductVersion.Version.String()),
+		url.PathEscape(filename))
+}
+
+// Download returns body of the named release file, which must be closed by the caller
+func (rf *ReleaseFiles) Download(ctx context.Context, filename string) (io.ReadCloser, error) {
+	fileURL := rf.URL(filename)
+	rf.log().Printf("downloading %s", fileURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %q: %w", fileURL, err)
+	}
+	if rf.HTTPClient == nil {
+		rf.HTTPClient = httpclient.NewHTTPClient(rf.log())
+	}
+	resp, err := rf.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download %q: %s", fileURL, resp.Status)
+	}
+
+	return resp.Body, nil
+}
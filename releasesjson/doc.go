@@ -0,0 +1,12 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package releasesjson provides a client for the releases site
+// (releases.hashicorp.com) or any mirror following the same layout,
+// i.e. with index.json files describing products, versions and builds.
+//
+// Besides listing products and versions, it allows retrieval
+// of checksums and their signatures and verified download
+// of archives, which is what sources in the releases package
+// are built on.
+package releasesjson
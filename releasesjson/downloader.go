@@ -0,0 +1,522 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package releasesjson
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/hashicorp/hc-install/internal/httpclient"
+)
+
+type Downloader struct {
+	Logger           *log.Logger
+	VerifyChecksum   bool
+	ArmoredPublicKey string
+	BaseURL          string
+
+	// AdditionalArmoredPublicKeys represents keys which are trusted
+	// alongside ArmoredPublicKey
+	AdditionalArmoredPublicKeys []string
+
+	// ChecksumVerifiers represents verifiers of the checksums
+	// which all have to succeed, defaulting to PGP verification
+	ChecksumVerifiers []ChecksumVerifier
+
+	// HTTPClient represents the client used for all requests,
+	// defaulting to a client which retries failed requests
+	HTTPClient *http.Client
+
+	// OS and Arch represent the platform of the build to download,
+	// defaulting to the platform hc-install is running on
+	OS   string
+	Arch string
+}
+
+type UnpackedProduct struct {
+	PathsToRemove []string
+
+	// Build is the build of the product which was downloaded
+	// from ArchiveURL
+	Build      *ProductBuild
+	ArchiveURL string
+
+	// ArchiveChecksum is the SHA256 checksum of the downloaded archive
+	ArchiveChecksum HashSum
+
+	// SigningKeyID and SigningKeyFingerprint identify the PGP key
+	// which signed the checksums, empty if checksums were not verified
+	SigningKeyID          string
+	SigningKeyFingerprint string
+
+	// LicensePaths are paths of unpacked license files
+	LicensePaths []string
+
+	// UnpackedPaths are paths of all unpacked files,
+	// including the binary and license files
+	UnpackedPaths []string
+
+	// BackupPaths maps paths of files which existed before unpacking
+	// to paths they were moved to (see BackupSuffix), such that
+	// they can be restored when the unpacked files are removed
+	BackupPaths map[string]string
+}
+
+// BackupSuffix is appended to the path of a file which would be
+// overwritten by unpacking, to obtain the path it is moved to,
+// followed by a number if such backup already exists
+const BackupSuffix = ".hc-install-backup"
+
+// DownloadedArchive describes an archive written by DownloadArchive
+type DownloadedArchive struct {
+	Build *ProductBuild
+
+	// ArchiveURL is the URL the archive was downloaded from
+	ArchiveURL string
+
+	// Checksum is the SHA256 checksum of the downloaded archive
+	Checksum HashSum
+
+	// Size is the number of bytes written
+	Size int64
+
+	// SigningKeyID and SigningKeyFingerprint identify the PGP key
+	// which signed the checksums, empty if checksums were not verified
+	SigningKeyID          string
+	SigningKeyFingerprint string
+}
+
+// DownloadArchive downloads the ZIP archive of the given product version
+// for the configured platform and writes it to w.
+//
+// If VerifyChecksum is true, the checksum of the archive is verified
+// against the signed checksums. The archive is staged in a temporary
+// file and only written to w once its size and checksum were verified,
+// so nothing is written to w if verification fails.
+func (d *Downloader) DownloadArchive(ctx context.Context, pv *ProductVersion, w io.Writer) (*DownloadedArchive, error) {
+	pb, err := d.FindBuild(pv)
+	if err != nil {
+		return nil, err
+	}
+
+	stagingFile, err := os.CreateTemp("", pb.Filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		stagingFile.Close()
+		err := os.Remove(stagingFile.Name())
+		if err != nil {
+			d.log().Printf("failed to delete staged archive at %s: %s", stagingFile.Name(), err)
+		}
+	}()
+
+	da, err := d.downloadArchive(ctx, pv, pb, stagingFile)
+	if err != nil {
+		return da, err
+	}
+
+	_, err = stagingFile.Seek(0, io.SeekStart)
+	if err != nil {
+		return da, err
+	}
+	_, err = io.Copy(w, stagingFile)
+	if err != nil {
+		return da, fmt.Errorf("failed to write %q: %w", pb.Filename, err)
+	}
+
+	return da, nil
+}
+
+// downloadArchive streams the archive into w, which therefore
+// receives unverified data, if an error is returned
+func (d *Downloader) downloadArchive(ctx context.Context, pv *ProductVersion, pb *ProductBuild, w io.Writer) (*DownloadedArchive, error) {
+	da := &DownloadedArchive{
+		Build: pb,
+	}
+
+	var verifiedChecksum HashSum
+	if d.VerifyChecksum {
+		v := &ChecksumDownloader{
+			BaseURL:                     d.BaseURL,
+			ProductVersion:              pv,
+			Logger:                      d.Logger,
+			ArmoredPublicKey:            d.ArmoredPublicKey,
+			AdditionalArmoredPublicKeys: d.AdditionalArmoredPublicKeys,
+			Verifiers:                   d.ChecksumVerifiers,
+			HTTPClient:                  d.HTTPClient,
+		}
+		verifiedChecksums, err := v.DownloadAndVerifyChecksums(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var ok bool
+		verifiedChecksum, ok = verifiedChecksums[pb.Filename]
+		if !ok {
+			return nil, fmt.Errorf("no checksum found for %q", pb.Filename)
+		}
+		da.SigningKeyID = v.SigningKeyID()
+		da.SigningKeyFingerprint = v.SigningKeyFingerprint()
+	}
+
+	client := d.HTTPClient
+	if client == nil {
+		client = httpclient.NewHTTPClient(d.log())
+	}
+
+	archiveURL, err := d.ArchiveURL(pb)
+	if err != nil {
+		return nil, err
+	}
+
+	da.ArchiveURL = archiveURL
+
+	d.log().Printf("downloading archive from %s", archiveURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, archiveURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %q: %w", archiveURL, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to download ZIP archive from %q: %s", archiveURL, resp.Status)
+	}
+
+	defer resp.Body.Close()
+
+	contentType := resp.Header.Get("content-type")
+	if !contentTypeIsZip(contentType) {
+		return nil, fmt.Errorf("unexpected content-type: %s (expected any of %q)",
+			contentType, zipMimeTypes)
+	}
+
+	expectedSize := resp.ContentLength
+
+	d.log().Printf("copying %q (%d bytes)", pb.Filename, expectedSize)
+
+	h := sha256.New()
+	da.Size, err = io.Copy(io.MultiWriter(w, h), resp.Body)
+	if err != nil {
+		d.log().Printf("failed to download %q: %s", pb.Filename, err)
+		return da, err
+	}
+	calculatedSum := h.Sum(nil)
+
+	if d.VerifyChecksum {
+		d.log().Printf("verifying checksum of %q", pb.Filename)
+		if !bytes.Equal(calculatedSum, verifiedChecksum) {
+			d.log().Printf("checksum does not match for %q (expected: %x, got: %x)",
+				pb.Filename, verifiedChecksum, calculatedSum)
+			return da, fmt.Errorf(
+				"checksum mismatch (expected: %x, got: %x)",
+				verifiedChecksum, calculatedSum,
+			)
+		}
+		d.log().Printf("checksum matches for %q", pb.Filename)
+	}
+	da.Checksum = calculatedSum
+
+	d.log().Printf("copied %d bytes of %q", da.Size, pb.Filename)
+
+	if expectedSize > 0 && da.Size != expectedSize {
+		return da, fmt.Errorf(
+			"unexpected size (downloaded: %d, expected: %d)",
+			da.Size, expectedSize,
+		)
+	}
+
+	return da, nil
+}
+
+func (d *Downloader) DownloadAndUnpack(ctx context.Context, pv *ProductVersion, binDir string, licenseDir string) (up *UnpackedProduct, err error) {
+	pb, err := d.FindBuild(pv)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgFile, err := os.CreateTemp("", pb.Filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		pkgFile.Close()
+		filePath := pkgFile.Name()
+		if rmErr := os.Remove(filePath); rmErr != nil {
+			d.log().Printf("failed to delete unpacked archive at %s: %s", filePath, rmErr)
+			if err == nil {
+				err = rmErr
+			}
+			return
+		}
+		d.log().Printf("deleted unpacked archive at %s", filePath)
+	}()
+
+	d.log().Printf("downloading %q to %s", pb.Filename, pkgFile.Name())
+
+	da, err := d.downloadArchive(ctx, pv, pb, pkgFile)
+	if err != nil {
+		if da != nil {
+			return &UnpackedProduct{}, err
+		}
+		return nil, err
+	}
+
+	up = &UnpackedProduct{
+		Build:                 da.Build,
+		ArchiveURL:            da.ArchiveURL,
+		ArchiveChecksum:       da.Checksum,
+		SigningKeyID:          da.SigningKeyID,
+		SigningKeyFingerprint: da.SigningKeyFingerprint,
+	}
+
+	r, err := zip.OpenReader(pkgFile.Name())
+	if err != nil {
+		return up, err
+	}
+	defer r.Close()
+
+	// a failed unpack leaves nothing behind
+	// and puts any overwritten files back
+	defer func() {
+		if err != nil {
+			d.rollback(up)
+		}
+	}()
+
+	for _, f := range r.File {
+		if strings.Contains(f.Name, "..") {
+			// While we generally trust the source ZIP file
+			// we still reject path traversal attempts as a precaution.
+			continue
+		}
+		// Determine the appropriate destination file path
+		dstDir := binDir
+		// for license files, use binDir if licenseDir is not set
+		if isLicenseFile(f.Name) && licenseDir != "" {
+			dstDir = licenseDir
+		}
+
+		d.log().Printf("unpacking %s to %s", f.Name, dstDir)
+		dstPath := filepath.Join(dstDir, f.Name)
+
+		backupPath, err := backupFile(dstPath)
+		if err != nil {
+			return up, err
+		}
+		if backupPath != "" {
+			d.log().Printf("moved existing %s to %s", dstPath, backupPath)
+			if up.BackupPaths == nil {
+				up.BackupPaths = make(map[string]string)
+			}
+			up.BackupPaths[dstPath] = backupPath
+		}
+
+		up.UnpackedPaths = append(up.UnpackedPaths, dstPath)
+		if isLicenseFile(f.Name) {
+			up.PathsToRemove = append(up.PathsToRemove, dstPath)
+			up.LicensePaths = append(up.LicensePaths, dstPath)
+		}
+
+		err = unpackFile(f, dstPath)
+		if err != nil {
+			return up, err
+		}
+	}
+
+	return up, nil
+}
+
+// rollback removes files unpacked so far (even if partially written)
+// and moves back any files they overwrote
+func (d *Downloader) rollback(up *UnpackedProduct) {
+	for i := len(up.UnpackedPaths) - 1; i >= 0; i-- {
+		path := up.UnpackedPaths[i]
+		err := os.Remove(path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			d.log().Printf("failed to remove %s: %s", path, err)
+			continue
+		}
+		if backupPath, ok := up.BackupPaths[path]; ok {
+			err = os.Rename(backupPath, path)
+			if err != nil {
+				d.log().Printf("failed to restore %s from %s: %s", path, backupPath, err)
+				continue
+			}
+			delete(up.BackupPaths, path)
+		}
+	}
+	up.UnpackedPaths = nil
+	up.PathsToRemove = nil
+	up.LicensePaths = nil
+}
+
+func unpackFile(f *zip.File, dstPath string) error {
+	srcFile, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	dstFile, err := os.Create(dstPath)
+	if err != nil {
+		return err
+	}
+	defer dstFile.Close()
+
+	_, err = io.Copy(dstFile, srcFile)
+	if err != nil {
+		return err
+	}
+	return dstFile.Close()
+}
+
+// backupFile moves an existing file at path aside and returns
+// the path of its backup, or empty string if there's no such file.
+// Any existing backup (e.g. of a previous install into the same
+// location which wasn't removed yet) is kept and a numbered backup
+// path is used instead, such that each backup is restored
+// by removal of the install which created it.
+func backupFile(path string) (string, error) {
+	fi, err := os.Lstat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	if fi.IsDir() {
+		return "", fmt.Errorf("unable to unpack into %s: is a directory", path)
+	}
+
+	backupPath := path + BackupSuffix
+	for i := 1; ; i++ {
+		_, err := os.Lstat(backupPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		backupPath = fmt.Sprintf("%s%s.%d", path, BackupSuffix, i)
+	}
+	err = os.Rename(path, backupPath)
+	if err != nil {
+		return "", err
+	}
+	return backupPath, nil
+}
+
+// FindBuild returns the ZIP archive build of the product version
+// for the configured platform
+func (d *Downloader) FindBuild(pv *ProductVersion) (*ProductBuild, error) {
+	if len(pv.Builds) == 0 {
+		return nil, fmt.Errorf("no builds found for %s %s", pv.Name, pv.Version)
+	}
+
+	goos, goarch := runtime.GOOS, runtime.GOARCH
+	if d.OS != "" {
+		goos = d.OS
+	}
+	if d.Arch != "" {
+		goarch = d.Arch
+	}
+
+	pb, ok := pv.Builds.FilterBuild(goos, goarch, "zip")
+	if !ok {
+		return nil, fmt.Errorf("no ZIP archive found for %s %s %s/%s",
+			pv.Name, pv.Version, goos, goarch)
+	}
+
+	return pb, nil
+}
+
+func (d *Downloader) log() *log.Logger {
+	if d.Logger == nil {
+		return discardLogger
+	}
+	return d.Logger
+}
+
+// The production release site uses consistent single mime type
+// but mime types are platform-dependent
+// and we may use different OS under test
+var zipMimeTypes = []string{
+	"application/x-zip-compressed", // Windows
+	"application/zip",              // Unix
+}
+
+func contentTypeIsZip(contentType string) bool {
+	for _, mt := range zipMimeTypes {
+		if mt == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// Product archives may have a few license files
+// which may be extracted to a separate directory
+// and may need to be tracked for later cleanup.
+var licenseFiles = []string{
+	"EULA.txt",
+	"TermsOfEvaluation.txt",
+	"LICENSE.txt",
+}
+
+func isLicenseFile(filename string) bool {
+	for _, lf := range licenseFiles {
+		if lf == filename {
+			return true
+		}
+	}
+	return false
+}
+
+// ArchiveURL returns the URL the archive of the build would be
+// downloaded from, taking BaseURL into account
+func (d *Downloader) ArchiveURL(pb *ProductBuild) (string, error) {
+	return determineArchiveURL(pb.URL, d.BaseURL)
+}
+
+// determineArchiveURL determines the archive URL based on the base URL provided.
+func determineArchiveURL(archiveURL, baseURL string) (string, error) {
+	// If custom URL is set, use that instead of the one from the JSON.
+	// Also ensures that absolute download links from mocked responses
+	// are still pointing to the mock server if one is set.
+	if baseURL == "" {
+		return archiveURL, nil
+	}
+
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(archiveURL)
+	if err != nil {
+		return "", err
+	}
+
+	// Use base URL path and append the path from the archive URL.
+	newArchiveURL := base.JoinPath(u.Path)
+
+	return newArchiveURL.String(), nil
+}
@@ -0,0 +1,278 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package releasesjson
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"hash/crc32"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/internal/testutil"
+)
+
+func TestDetermineArchiveURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		archiveURL string
+		baseURL    string
+		want       string
+	}{
+		{
+			name:       "with custom base URL + path",
+			archiveURL: "https://releases.hashicorp.com/terraform/1.8.2/terraform_1.8.2_darwin_amd64.zip",
+			baseURL:    "https://myartifactory.company.com/artifactory/hashicorp-remote",
+			want:       "https://myartifactory.company.com/artifactory/hashicorp-remote/terraform/1.8.2/terraform_1.8.2_darwin_amd64.zip",
+		},
+		{
+			name:       "with custom base URL + port + path",
+			archiveURL: "https://releases.hashicorp.com/terraform/1.8.2/terraform_1.8.2_darwin_amd64.zip",
+			baseURL:    "https://myartifactory.company.com:443/artifactory/hashicorp-remote",
+			want:       "https://myartifactory.company.com:443/artifactory/hashicorp-remote/terraform/1.8.2/terraform_1.8.2_darwin_amd64.zip",
+		},
+		{
+			name:       "without custom base URL",
+			archiveURL: "https://releases.hashicorp.com/terraform/1.8.2/terraform_1.8.2_darwin_amd64.zip",
+			baseURL:    "",
+			want:       "https://releases.hashicorp.com/terraform/1.8.2/terraform_1.8.2_darwin_amd64.zip",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := determineArchiveURL(tt.archiveURL, tt.baseURL)
+			if err != nil {
+				t.Errorf("determineArchiveURL() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("determineArchiveURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDownloader_DownloadArchive(t *testing.T) {
+	mockApiRoot := filepath.Join("..", "releases", "testdata", "mock_api_tf_0_14_with_prereleases")
+	srv := testutil.NewTestServer(t, mockApiRoot)
+
+	r := &Releases{BaseURL: srv.URL}
+	pv, err := r.GetProductVersion(context.Background(), "terraform", version.Must(version.NewVersion("0.14.11")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := &Downloader{
+		Logger:           testutil.TestLogger(),
+		VerifyChecksum:   true,
+		ArmoredPublicKey: readTestPubKey(t),
+		BaseURL:          srv.URL,
+		OS:               "linux",
+		Arch:             "amd64",
+	}
+
+	var buf bytes.Buffer
+	da, err := d.DownloadArchive(context.Background(), pv, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedArchive, err := os.ReadFile(filepath.Join(mockApiRoot, "terraform", "0.14.11", "terraform_0.14.11_linux_amd64.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(expectedArchive, buf.Bytes()) {
+		t.Fatal("downloaded archive doesn't match")
+	}
+
+	expectedSum := sha256.Sum256(expectedArchive)
+	if !bytes.Equal(expectedSum[:], da.Checksum) {
+		t.Fatalf("unexpected checksum: %s", da.Checksum)
+	}
+	if da.Size != int64(len(expectedArchive)) {
+		t.Fatalf("unexpected size: %d", da.Size)
+	}
+	if da.Build.Filename != "terraform_0.14.11_linux_amd64.zip" {
+		t.Fatalf("unexpected build: %q", da.Build.Filename)
+	}
+	if da.SigningKeyID == "" {
+		t.Fatal("expected signing key ID")
+	}
+}
+
+func TestDownloader_DownloadArchive_checksumMismatch(t *testing.T) {
+	mockApiRoot := filepath.Join("..", "releases", "testdata", "mock_api_tf_0_14_with_prereleases")
+	srv := testutil.NewTestServer(t, mockApiRoot)
+
+	r := &Releases{BaseURL: srv.URL}
+	pv, err := r.GetProductVersion(context.Background(), "terraform", version.Must(version.NewVersion("0.14.11")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// point the linux build to a different archive
+	for _, pb := range pv.Builds {
+		if pb.OS == "linux" && pb.Arch == "amd64" {
+			pb.URL = strings.Replace(pb.URL, "linux_amd64", "darwin_amd64", 1)
+		}
+	}
+
+	d := &Downloader{
+		VerifyChecksum:   true,
+		ArmoredPublicKey: readTestPubKey(t),
+		BaseURL:          srv.URL,
+		OS:               "linux",
+		Arch:             "amd64",
+	}
+
+	var buf bytes.Buffer
+	_, err = d.DownloadArchive(context.Background(), pv, &buf)
+	if err == nil {
+		t.Fatal("expected checksum mismatch error")
+	}
+	if !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("expected no unverified data to be written, got %d bytes", buf.Len())
+	}
+}
+
+func TestDownloader_DownloadAndUnpack_checksumMismatch(t *testing.T) {
+	mockApiRoot := filepath.Join("..", "releases", "testdata", "mock_api_tf_0_14_with_prereleases")
+	srv := testutil.NewTestServer(t, mockApiRoot)
+
+	r := &Releases{BaseURL: srv.URL}
+	pv, err := r.GetProductVersion(context.Background(), "terraform", version.Must(version.NewVersion("0.14.11")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// point the linux build to a different archive
+	for _, pb := range pv.Builds {
+		if pb.OS == "linux" && pb.Arch == "amd64" {
+			pb.URL = strings.Replace(pb.URL, "linux_amd64", "darwin_amd64", 1)
+		}
+	}
+
+	d := &Downloader{
+		Logger:           testutil.TestLogger(),
+		VerifyChecksum:   true,
+		ArmoredPublicKey: readTestPubKey(t),
+		BaseURL:          srv.URL,
+		OS:               "linux",
+		Arch:             "amd64",
+	}
+
+	binDir := t.TempDir()
+	up, err := d.DownloadAndUnpack(context.Background(), pv, binDir, "")
+	if err == nil {
+		t.Fatal("expected checksum mismatch error")
+	}
+	if !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if up != nil && len(up.UnpackedPaths) > 0 {
+		t.Fatalf("expected nothing to be unpacked, got %q", up.UnpackedPaths)
+	}
+	assertDirContents(t, binDir, map[string]string{})
+}
+
+func TestDownloader_DownloadAndUnpack_unpackFailure(t *testing.T) {
+	// archive whose content doesn't match the CRC-32 in its header,
+	// such that unpacking fails after writing all the content
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	content := []byte("corrupted binary")
+	w, err := zw.CreateRaw(&zip.FileHeader{
+		Name:               "terraform",
+		Method:             zip.Store,
+		CRC32:              crc32.ChecksumIEEE(content) + 1,
+		CompressedSize64:   uint64(len(content)),
+		UncompressedSize64: uint64(len(content)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = w.Write(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = zw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		w.Write(archive.Bytes())
+	}))
+	t.Cleanup(srv.Close)
+
+	pv := &ProductVersion{
+		Name:    "terraform",
+		Version: version.Must(version.NewVersion("0.14.11")),
+		Builds: ProductBuilds{
+			{
+				Name:     "terraform",
+				Version:  "0.14.11",
+				OS:       "linux",
+				Arch:     "amd64",
+				Filename: "terraform_0.14.11_linux_amd64.zip",
+				URL:      srv.URL + "/terraform_0.14.11_linux_amd64.zip",
+			},
+		},
+	}
+	d := &Downloader{
+		Logger: testutil.TestLogger(),
+		OS:     "linux",
+		Arch:   "amd64",
+	}
+
+	binDir := t.TempDir()
+	err = os.WriteFile(filepath.Join(binDir, "terraform"), []byte("existing"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	up, err := d.DownloadAndUnpack(context.Background(), pv, binDir, "")
+	if !errors.Is(err, zip.ErrChecksum) {
+		t.Fatalf("expected unpack to fail, got: %v", err)
+	}
+	if len(up.UnpackedPaths) > 0 || len(up.BackupPaths) > 0 {
+		t.Fatalf("expected unpacked files to be rolled back, got %#v", up)
+	}
+	// overwritten binary is put back, without any backup left behind
+	assertDirContents(t, binDir, map[string]string{"terraform": "existing"})
+}
+
+func assertDirContents(t *testing.T, dir string, expected map[string]string) {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %d files in %s, found %d", len(expected), dir, len(entries))
+	}
+	for name, expectedContent := range expected {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != expectedContent {
+			t.Fatalf("expected %s to contain %q, got %q", name, expectedContent, b)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package releasesjson
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/hc-install/internal/atomicfile"
+)
+
+// IndexCache caches index.json files on disk and revalidates them
+// using conditional requests (ETag and Last-Modified), such that
+// unchanged files don't have to be downloaded again.
+type IndexCache struct {
+	// Dir represents the directory where the files are cached
+	Dir string
+
+	// TTL represents how long a cached file is used
+	// without revalidation (zero means always revalidate)
+	TTL time.Duration
+
+	// StaleIfError represents for how long after the TTL has expired
+	// a cached file may still be used when it can't be revalidated,
+	// e.g. when the releases site is unreachable
+	// (zero means never, negative means without limit)
+	StaleIfError time.Duration
+}
+
+type indexCacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	ValidatedAt  time.Time `json:"validated_at"`
+	Body         []byte    `json:"body"`
+}
+
+func (ic *IndexCache) get(ctx context.Context, client *http.Client, logger *log.Logger, indexURL string) ([]byte, error) {
+	entry, err := ic.load(indexURL)
+	if err != nil {
+		// a broken cache shouldn't prevent obtaining the file
+		logger.Printf("ignoring cached %s: %s", indexURL, err)
+		entry = nil
+	}
+
+	now := time.Now()
+	if entry != nil && now.Sub(entry.ValidatedAt) < ic.TTL {
+		logger.Printf("using cached %s", indexURL)
+		return entry.Body, nil
+	}
+
+	header := make(http.Header, 0)
+	if entry != nil {
+		if entry.ETag != "" {
+			header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := requestJSON(ctx, client, logger, indexURL, header)
+	if err != nil {
+		if entry != nil && ic.staleAllowed(entry, now) {
+			logger.Printf("using stale cached %s (validated at %s): %s",
+				indexURL, entry.ValidatedAt, err)
+			return entry.Body, nil
+		}
+		return nil, err
+	}
+
+	if resp.notModified {
+		if entry == nil {
+			return nil, fmt.Errorf("unexpected response from %q: not modified", indexURL)
+		}
+		logger.Printf("cached %s is up to date", indexURL)
+	} else {
+		entry = &indexCacheEntry{
+			URL:          indexURL,
+			ETag:         resp.header.Get("ETag"),
+			LastModified: resp.header.Get("Last-Modified"),
+			Body:         resp.body,
+		}
+	}
+	entry.ValidatedAt = now
+
+	err = ic.store(entry)
+	if err != nil {
+		logger.Printf("failed to cache %s: %s", indexURL, err)
+	}
+
+	return entry.Body, nil
+}
+
+func (ic *IndexCache) staleAllowed(entry *indexCacheEntry, now time.Time) bool {
+	if ic.StaleIfError < 0 {
+		return true
+	}
+	return now.Sub(entry.ValidatedAt) < ic.TTL+ic.StaleIfError
+}
+
+func (ic *IndexCache) load(indexURL string) (*indexCacheEntry, error) {
+	b, err := os.ReadFile(ic.entryPath(indexURL))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entry := &indexCacheEntry{}
+	err = json.Unmarshal(b, entry)
+	if err != nil {
+		return nil, err
+	}
+	if entry.URL != indexURL {
+		return nil, fmt.Errorf("unexpected URL %q", entry.URL)
+	}
+
+	return entry, nil
+}
+
+func (ic *IndexCache) store(entry *indexCacheEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(ic.Dir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	return atomicfile.Write(ic.entryPath(entry.URL), b)
+}
+
+func (ic *IndexCache) entryPath(indexURL string) string {
+	sum := sha256.Sum256([]byte(indexURL))
+	return filepath.Join(ic.Dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,193 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package releasesjson
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hc-install/internal/testutil"
+)
+
+const testProductIndex = `{
+	"name": "terraform",
+	"versions": {
+		"0.14.11": {"name": "terraform", "version": "0.14.11"}
+	}
+}`
+
+type indexServer struct {
+	*httptest.Server
+
+	requests     atomic.Int32
+	notModified  atomic.Int32
+	unavailable  atomic.Bool
+	lastModified string
+}
+
+func newIndexServer(t *testing.T, etag string) *indexServer {
+	is := &indexServer{
+		lastModified: time.Date(2021, 4, 26, 12, 0, 0, 0, time.UTC).Format(http.TimeFormat),
+	}
+	is.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		is.requests.Add(1)
+		if is.unavailable.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		if etag != "" {
+			w.Header().Set("ETag", etag)
+			if r.Header.Get("If-None-Match") == etag {
+				is.notModified.Add(1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		} else {
+			w.Header().Set("Last-Modified", is.lastModified)
+			if r.Header.Get("If-Modified-Since") == is.lastModified {
+				is.notModified.Add(1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, testProductIndex)
+	}))
+	t.Cleanup(is.Close)
+	return is
+}
+
+func TestIndexCache_revalidation(t *testing.T) {
+	testCases := map[string]string{
+		"etag":          `"abc123"`,
+		"last-modified": "",
+	}
+
+	for name, etag := range testCases {
+		etag := etag
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := newIndexServer(t, etag)
+			r := &Releases{
+				BaseURL: srv.URL,
+				Cache:   &IndexCache{Dir: t.TempDir()},
+			}
+			r.SetLogger(testutil.TestLogger())
+
+			ctx := context.Background()
+			for i := 0; i < 3; i++ {
+				pvs, err := r.ListProductVersions(ctx, "terraform")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, ok := pvs["0.14.11"]; !ok {
+					t.Fatalf("expected version 0.14.11 in %d. response", i+1)
+				}
+			}
+
+			if got := srv.requests.Load(); got != 3 {
+				t.Fatalf("expected 3 requests, %d made", got)
+			}
+			if got := srv.notModified.Load(); got != 2 {
+				t.Fatalf("expected 2 not modified responses, %d given", got)
+			}
+		})
+	}
+}
+
+func TestIndexCache_ttl(t *testing.T) {
+	srv := newIndexServer(t, `"abc123"`)
+	r := &Releases{
+		BaseURL: srv.URL,
+		Cache: &IndexCache{
+			Dir: t.TempDir(),
+			TTL: time.Hour,
+		},
+	}
+
+	ctx := context.Background()
+	first, err := r.ListProductVersions(ctx, "terraform")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := r.ListProductVersions(ctx, "terraform")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := srv.requests.Load(); got != 1 {
+		t.Fatalf("expected 1 request, %d made", got)
+	}
+	if diff := cmp.Diff(first, second); diff != "" {
+		t.Fatalf("unexpected cached versions: %s", diff)
+	}
+}
+
+func TestIndexCache_staleIfError(t *testing.T) {
+	testCases := map[string]struct {
+		staleIfError time.Duration
+		expectErr    bool
+	}{
+		"allowed": {
+			staleIfError: -1,
+		},
+		"within limit": {
+			staleIfError: time.Hour,
+		},
+		"disallowed": {
+			staleIfError: 0,
+			expectErr:    true,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := newIndexServer(t, `"abc123"`)
+			r := &Releases{
+				BaseURL: srv.URL,
+				Cache: &IndexCache{
+					Dir:          t.TempDir(),
+					StaleIfError: tc.staleIfError,
+				},
+			}
+
+			_, err := r.ListProductVersions(context.Background(), "terraform")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			srv.unavailable.Store(true)
+
+			// avoid waiting for all retries
+			ctx, cancelFunc := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			t.Cleanup(cancelFunc)
+
+			pvs, err := r.ListProductVersions(ctx, "terraform")
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected error when releases site is unavailable")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := pvs["0.14.11"]; !ok {
+				t.Fatal("expected version 0.14.11 from stale cache")
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package releasesjson
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/hashicorp/go-multierror"
+)
+
+// PGPVerifier verifies detached PGP signatures of the checksums
+// made by any of the trusted keys which are neither revoked nor expired
+type PGPVerifier struct {
+	// ArmoredPublicKeys represents trusted public keys in ASCII/armor format
+	ArmoredPublicKeys []string
+
+	Logger *log.Logger
+}
+
+func (pv *PGPVerifier) VerifyChecksums(ctx context.Context, rf *ReleaseFiles, checksums []byte) (*ChecksumVerification, error) {
+	el, err := pv.keyEntityList()
+	if err != nil {
+		return nil, err
+	}
+
+	sigFilenames, err := findSigFilenames(rf.ProductVersion, el)
+	if err != nil {
+		return nil, err
+	}
+
+	// Any of the signatures may be made by a key we trust
+	// so we try all of them before giving up
+	var errs *multierror.Error
+	for _, sigFilename := range sigFilenames {
+		key, err := pv.verifySignatureFile(ctx, rf, sigFilename, checksums, el)
+		if err != nil {
+			pv.log().Printf("unable to verify checksums using %s: %s", sigFilename, err)
+			errs = multierror.Append(errs, fmt.Errorf("%s: %w", sigFilename, err))
+			continue
+		}
+
+		return &ChecksumVerification{
+			Method:         "pgp",
+			KeyID:          key.PublicKey.KeyIdString(),
+			KeyFingerprint: strings.ToUpper(hex.EncodeToString(key.PublicKey.Fingerprint)),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unable to verify checksums signature: %w", errs.ErrorOrNil())
+}
+
+func (pv *PGPVerifier) verifySignatureFile(ctx context.Context, rf *ReleaseFiles, sigFilename string,
+	checksums []byte, el openpgp.EntityList) (*openpgp.Key, error) {
+	sigBody, err := rf.Download(ctx, sigFilename)
+	if err != nil {
+		return nil, err
+	}
+	defer sigBody.Close()
+
+	sig, _, err := openpgp.VerifyDetachedSignature(el, bytes.NewReader(checksums), sigBody, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := signingKey(el, sig, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	pv.log().Printf("checksum signature is valid (signed by %s of %s)",
+		key.PublicKey.KeyIdString(), key.Entity.PrimaryKey.KeyIdString())
+
+	return key, nil
+}
+
+// signingKey returns the key which made the signature, which
+// may be a subkey of a trusted key, provided it is neither
+// revoked nor expired on its own
+func signingKey(el openpgp.EntityList, sig *packet.Signature, now time.Time) (*openpgp.Key, error) {
+	if sig.IssuerKeyId == nil {
+		return nil, fmt.Errorf("signature doesn't have an issuer")
+	}
+
+	keys := make([]openpgp.Key, 0)
+	for _, key := range el.KeysByIdUsage(*sig.IssuerKeyId, packet.KeyFlagSign) {
+		if sig.IssuerFingerprint != nil && !bytes.Equal(sig.IssuerFingerprint, key.PublicKey.Fingerprint) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("unknown signing key %016X", *sig.IssuerKeyId)
+	}
+	if len(keys) > 1 {
+		return nil, fmt.Errorf("ambiguous signing key %016X", *sig.IssuerKeyId)
+	}
+	key := keys[0]
+
+	keyID := key.PublicKey.KeyIdString()
+	if key.Revoked(now) {
+		return nil, fmt.Errorf("signing key %s is revoked", keyID)
+	}
+	if key.PublicKey != key.Entity.PrimaryKey {
+		if key.SelfSignature == nil {
+			return nil, fmt.Errorf("signing key %s has no valid binding signature", keyID)
+		}
+		if key.PublicKey.KeyExpired(key.SelfSignature, now) {
+			return nil, fmt.Errorf("signing key %s is expired", keyID)
+		}
+	}
+
+	return &key, nil
+}
+
+func (pv *PGPVerifier) log() *log.Logger {
+	if pv.Logger == nil {
+		return discardLogger
+	}
+	return pv.Logger
+}
+
+// findSigFilenames returns all signature files of the given
+// product version which may have been produced by any of the keys,
+// in the order in which they are listed
+func findSigFilenames(pv *ProductVersion, el openpgp.EntityList) ([]string, error) {
+	sigFiles := pv.SHASUMSSigs
+	if len(sigFiles) == 0 {
+		sigFiles = []string{pv.SHASUMSSig}
+	}
+
+	filenames := make([]string, 0)
+	for _, filename := range sigFiles {
+		if strings.HasSuffix(filename, "_SHA256SUMS.sig") {
+			filenames = append(filenames, filename)
+			continue
+		}
+		for _, entity := range el {
+			keyID := entity.PrimaryKey.KeyIdShortString()
+			if strings.HasSuffix(filename, fmt.Sprintf("_SHA256SUMS.%s.sig", keyID)) {
+				filenames = append(filenames, filename)
+				break
+			}
+		}
+	}
+
+	if len(filenames) == 0 {
+		return nil, fmt.Errorf("no suitable sig file found")
+	}
+
+	return filenames, nil
+}
+
+// keyEntityList returns all trusted keys which are
+// neither revoked nor expired
+func (pv *PGPVerifier) keyEntityList() (openpgp.EntityList, error) {
+	if len(pv.ArmoredPublicKeys) == 0 {
+		return nil, fmt.Errorf("no public key provided")
+	}
+
+	now := time.Now()
+	validEntities := make(openpgp.EntityList, 0)
+	var errs *multierror.Error
+	for _, armoredKey := range pv.ArmoredPublicKeys {
+		el, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armoredKey))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entity := range el {
+			if err := checkEntityValidity(entity, now); err != nil {
+				pv.log().Printf("ignoring untrusted key: %s", err)
+				errs = multierror.Append(errs, err)
+				continue
+			}
+			validEntities = append(validEntities, entity)
+		}
+	}
+
+	if len(validEntities) == 0 {
+		return nil, fmt.Errorf("no valid public key provided: %w", errs.ErrorOrNil())
+	}
+
+	return validEntities, nil
+}
+
+func checkEntityValidity(entity *openpgp.Entity, now time.Time) error {
+	keyID := entity.PrimaryKey.KeyIdString()
+
+	if entity.Revoked(now) {
+		return fmt.Errorf("key %s is revoked", keyID)
+	}
+
+	selfSig, _ := entity.PrimarySelfSignature()
+	if selfSig == nil {
+		return fmt.Errorf("key %s has no valid self-signature", keyID)
+	}
+	if entity.PrimaryKey.KeyExpired(selfSig, now) {
+		return fmt.Errorf("key %s is expired", keyID)
+	}
+
+	return nil
+}
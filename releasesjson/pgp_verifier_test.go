@@ -0,0 +1,308 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package releasesjson
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/internal/testutil"
+)
+
+func TestFindSigFilenames(t *testing.T) {
+	el := testKeyring(t)
+
+	pv := &ProductVersion{
+		SHASUMSSig: "terraform_0.14.11_SHA256SUMS.sig",
+		SHASUMSSigs: []string{
+			"terraform_0.14.11_SHA256SUMS.72D7468F.sig",
+			"terraform_0.14.11_SHA256SUMS.2FCA0A85.sig",
+			"terraform_0.14.11_SHA256SUMS.sig",
+		},
+	}
+
+	filenames, err := findSigFilenames(pv, el)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedFilenames := []string{
+		"terraform_0.14.11_SHA256SUMS.2FCA0A85.sig",
+		"terraform_0.14.11_SHA256SUMS.sig",
+	}
+	if diff := cmp.Diff(expectedFilenames, filenames); diff != "" {
+		t.Fatalf("unexpected filenames: %s", diff)
+	}
+}
+
+func TestFindSigFilenames_noneSuitable(t *testing.T) {
+	el := testKeyring(t)
+
+	pv := &ProductVersion{
+		SHASUMSSigs: []string{
+			"terraform_0.14.11_SHA256SUMS.72D7468F.sig",
+		},
+	}
+
+	_, err := findSigFilenames(pv, el)
+	if err == nil {
+		t.Fatal("expected error for no suitable sig file")
+	}
+}
+
+func TestPGPVerifier_keyEntityList_additionalKeys(t *testing.T) {
+	testKey := readTestPubKey(t)
+	otherKey := armoredPublicKey(t, newTestEntity(t, nil))
+
+	pv := &PGPVerifier{
+		Logger:            testutil.TestLogger(),
+		ArmoredPublicKeys: []string{otherKey, testKey},
+	}
+
+	el, err := pv.keyEntityList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(el) != 2 {
+		t.Fatalf("expected 2 trusted keys, got %d", len(el))
+	}
+}
+
+func TestPGPVerifier_keyEntityList_ignoresRevokedKeys(t *testing.T) {
+	revoked := newTestEntity(t, nil)
+	err := revoked.RevokeKey(packet.KeyCompromised, "compromised", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pv := &PGPVerifier{
+		Logger:            testutil.TestLogger(),
+		ArmoredPublicKeys: []string{armoredPublicKey(t, revoked), readTestPubKey(t)},
+	}
+
+	el, err := pv.keyEntityList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(el) != 1 || !strings.HasSuffix(el[0].PrimaryKey.KeyIdString(), "2FCA0A85") {
+		t.Fatalf("expected only the test key to be trusted, got %d keys", len(el))
+	}
+
+	pv.ArmoredPublicKeys = pv.ArmoredPublicKeys[:1]
+	_, err = pv.keyEntityList()
+	if err == nil {
+		t.Fatal("expected error when all keys are revoked")
+	}
+}
+
+func TestCheckEntityValidity(t *testing.T) {
+	valid := newTestEntity(t, nil)
+	if err := checkEntityValidity(valid, time.Now()); err != nil {
+		t.Fatalf("expected key to be valid: %s", err)
+	}
+
+	expired := newTestEntity(t, &packet.Config{
+		KeyLifetimeSecs: 60,
+		Time: func() time.Time {
+			return time.Now().Add(-1 * time.Hour)
+		},
+	})
+	err := checkEntityValidity(expired, time.Now())
+	if err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Fatalf("expected key to be expired, got: %v", err)
+	}
+}
+
+func TestPGPVerifier_VerifyChecksums_subkey(t *testing.T) {
+	entity := newTestEntity(t, nil)
+	err := entity.AddSigningSubkey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subkey := entity.Subkeys[len(entity.Subkeys)-1].PublicKey
+
+	checksums := []byte("abc123  terraform_0.14.11_linux_amd64.zip\n")
+	var sig bytes.Buffer
+	err = openpgp.DetachSign(&sig, entity, bytes.NewReader(checksums), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/terraform/0.14.11/terraform_0.14.11_SHA256SUMS.sig" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(sig.Bytes())
+	}))
+	t.Cleanup(srv.Close)
+
+	rf := &ReleaseFiles{
+		ProductVersion: &ProductVersion{
+			Name:       "terraform",
+			Version:    version.Must(version.NewVersion("0.14.11")),
+			SHASUMSSig: "terraform_0.14.11_SHA256SUMS.sig",
+		},
+		BaseURL:    srv.URL,
+		HTTPClient: srv.Client(),
+		logger:     testutil.TestLogger(),
+	}
+
+	pv := &PGPVerifier{
+		Logger:            testutil.TestLogger(),
+		ArmoredPublicKeys: []string{armoredPublicKey(t, entity)},
+	}
+	verification, err := pv.VerifyChecksums(context.Background(), rf, checksums)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if verification.KeyID != subkey.KeyIdString() {
+		t.Fatalf("expected subkey %s to be reported, got %s (primary key is %s)",
+			subkey.KeyIdString(), verification.KeyID, entity.PrimaryKey.KeyIdString())
+	}
+	expectedFingerprint := strings.ToUpper(hex.EncodeToString(subkey.Fingerprint))
+	if verification.KeyFingerprint != expectedFingerprint {
+		t.Fatalf("unexpected fingerprint: %q, expected %q", verification.KeyFingerprint, expectedFingerprint)
+	}
+}
+
+func TestSigningKey(t *testing.T) {
+	hourAgo := &packet.Config{
+		KeyLifetimeSecs: 60,
+		Time: func() time.Time {
+			return time.Now().Add(-1 * time.Hour)
+		},
+	}
+
+	testCases := map[string]struct {
+		setup       func(t *testing.T, e *openpgp.Entity) *packet.PublicKey
+		expectedErr string
+	}{
+		"primary-key": {
+			setup: func(t *testing.T, e *openpgp.Entity) *packet.PublicKey {
+				return e.PrimaryKey
+			},
+		},
+		"subkey": {
+			setup: func(t *testing.T, e *openpgp.Entity) *packet.PublicKey {
+				return addSigningSubkey(t, e, nil).PublicKey
+			},
+		},
+		"revoked-subkey": {
+			setup: func(t *testing.T, e *openpgp.Entity) *packet.PublicKey {
+				sk := addSigningSubkey(t, e, nil)
+				err := e.RevokeSubkey(sk, packet.KeyCompromised, "compromised", nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return sk.PublicKey
+			},
+			expectedErr: "is revoked",
+		},
+		"expired-subkey": {
+			setup: func(t *testing.T, e *openpgp.Entity) *packet.PublicKey {
+				return addSigningSubkey(t, e, hourAgo).PublicKey
+			},
+			expectedErr: "is expired",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			entity := newTestEntity(t, nil)
+			pk := testCase.setup(t, entity)
+
+			// round-trip the key as verifiers only ever see public keys
+			el, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armoredPublicKey(t, entity)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			key, err := signingKey(el, &packet.Signature{
+				IssuerKeyId:       &pk.KeyId,
+				IssuerFingerprint: pk.Fingerprint,
+			}, time.Now())
+			if testCase.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.expectedErr) {
+					t.Fatalf("expected error containing %q, got: %v", testCase.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if key.PublicKey.KeyId != pk.KeyId {
+				t.Fatalf("expected key %s, got %s", pk.KeyIdString(), key.PublicKey.KeyIdString())
+			}
+		})
+	}
+}
+
+func addSigningSubkey(t *testing.T, entity *openpgp.Entity, config *packet.Config) *openpgp.Subkey {
+	t.Helper()
+	err := entity.AddSigningSubkey(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &entity.Subkeys[len(entity.Subkeys)-1]
+}
+
+func newTestEntity(t *testing.T, config *packet.Config) *openpgp.Entity {
+	t.Helper()
+	entity, err := openpgp.NewEntity("hc-install test", "", "test@example.com", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entity
+}
+
+func armoredPublicKey(t *testing.T, entity *openpgp.Entity) string {
+	t.Helper()
+	var sb strings.Builder
+	w, err := armor.Encode(&sb, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = entity.Serialize(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sb.String()
+}
+
+func readTestPubKey(t *testing.T) string {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("..", "releases", "testdata", "2FCA0A85.pub"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func testKeyring(t *testing.T) openpgp.EntityList {
+	t.Helper()
+	el, err := openpgp.ReadArmoredKeyRing(strings.NewReader(readTestPubKey(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return el
+}
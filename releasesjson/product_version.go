@@ -14,6 +14,10 @@ type ProductVersion struct {
 	SHASUMSSig  string           `json:"shasums_signature,omitempty"`
 	SHASUMSSigs []string         `json:"shasums_signatures,omitempty"`
 	Builds      ProductBuilds    `json:"builds"`
+
+	// Metadata represents details of the release, only available
+	// if the version was obtained via ReleasesAPI
+	Metadata *ReleaseMetadata `json:"-"`
 }
 
 type ProductVersionsMap map[string]*ProductVersion
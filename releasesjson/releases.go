@@ -0,0 +1,236 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package releasesjson
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/internal/httpclient"
+)
+
+const defaultBaseURL = "https://releases.hashicorp.com"
+
+var discardLogger = log.New(io.Discard, "", 0)
+
+// Product is a top-level product like "Consul" or "Nomad". A Product may have
+// one or more versions.
+type Product struct {
+	Name     string             `json:"name"`
+	Versions ProductVersionsMap `json:"versions"`
+}
+
+type ProductBuilds []*ProductBuild
+
+func (pbs ProductBuilds) FilterBuild(os string, arch string, suffix string) (*ProductBuild, bool) {
+	for _, pb := range pbs {
+		if pb.OS == os && pb.Arch == arch && strings.HasSuffix(pb.Filename, suffix) {
+			return pb, true
+		}
+	}
+	return nil, false
+}
+
+// ProductBuild is an OS/arch-specific representation of a product. This is the
+// actual file that a user would download, like "consul_0.5.1_linux_amd64".
+type ProductBuild struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	Filename string `json:"filename"`
+	URL      string `json:"url"`
+}
+
+type Releases struct {
+	logger  *log.Logger
+	BaseURL string
+
+	// Cache represents an optional on-disk cache of index.json files
+	Cache *IndexCache
+
+	// HTTPClient represents the client used for all requests,
+	// defaulting to a client which retries failed requests
+	HTTPClient *http.Client
+}
+
+func NewReleases() *Releases {
+	return &Releases{
+		logger:  discardLogger,
+		BaseURL: defaultBaseURL,
+	}
+}
+
+func (r *Releases) SetLogger(logger *log.Logger) {
+	r.logger = logger
+}
+
+func (r *Releases) log() *log.Logger {
+	if r.logger == nil {
+		return discardLogger
+	}
+	return r.logger
+}
+
+func (r *Releases) httpClient() *http.Client {
+	if r.HTTPClient != nil {
+		return r.HTTPClient
+	}
+	return httpclient.NewHTTPClient(r.log())
+}
+
+func (r *Releases) baseURL() string {
+	if r.BaseURL == "" {
+		return defaultBaseURL
+	}
+	return r.BaseURL
+}
+
+// ListProducts returns names of all products published on the releases site.
+// Note that this downloads index of all products and their versions,
+// which may be large.
+func (r *Releases) ListProducts(ctx context.Context) ([]string, error) {
+	indexURL := fmt.Sprintf("%s/index.json", r.baseURL())
+	r.log().Printf("requesting products from %s", indexURL)
+
+	products := make(map[string]json.RawMessage, 0)
+	err := r.getJSON(ctx, indexURL, &products)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain products: %w", err)
+	}
+
+	names := make([]string, 0, len(products))
+	for name := range products {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (r *Releases) ListProductVersions(ctx context.Context, productName string) (ProductVersionsMap, error) {
+	productIndexURL := fmt.Sprintf("%s/%s/index.json",
+		r.baseURL(),
+		url.PathEscape(productName))
+	r.log().Printf("requesting versions from %s", productIndexURL)
+
+	p := Product{}
+	err := r.getJSON(ctx, productIndexURL, &p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain product versions: %w", err)
+	}
+
+	for rawVersion := range p.Versions {
+		v, err := version.NewVersion(rawVersion)
+		if err != nil {
+			// remove unparseable version
+			delete(p.Versions, rawVersion)
+			continue
+		}
+
+		p.Versions[rawVersion].Version = v
+	}
+
+	return p.Versions, nil
+}
+
+func (r *Releases) GetProductVersion(ctx context.Context, product string, version *version.Version) (*ProductVersion, error) {
+	indexURL := fmt.Sprintf("%s/%s/%s/index.json",
+		r.baseURL(),
+		url.PathEscape(product),
+		url.PathEscape(version.String()))
+	r.log().Printf("requesting version from %s", indexURL)
+
+	pv := &ProductVersion{}
+	err := r.getJSON(ctx, indexURL, pv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain product version: %w", err)
+	}
+
+	return pv, nil
+}
+
+func (r *Releases) getJSON(ctx context.Context, indexURL string, v interface{}) error {
+	if r.Cache == nil {
+		return getJSON(ctx, r.httpClient(), r.log(), indexURL, v)
+	}
+
+	body, err := r.Cache.get(ctx, r.httpClient(), r.log(), indexURL)
+	if err != nil {
+		return err
+	}
+	return unmarshalJSON(body, v)
+}
+
+func getJSON(ctx context.Context, client *http.Client, logger *log.Logger, indexURL string, v interface{}) error {
+	resp, err := requestJSON(ctx, client, logger, indexURL, nil)
+	if err != nil {
+		return err
+	}
+	return unmarshalJSON(resp.body, v)
+}
+
+type jsonResponse struct {
+	notModified bool
+	header      http.Header
+	body        []byte
+}
+
+// requestJSON requests JSON document at the given URL with the given
+// (e.g. conditional) request headers and returns its body,
+// or no body if the document was not modified
+func requestJSON(ctx context.Context, client *http.Client, logger *log.Logger, indexURL string, header http.Header) (*jsonResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, indexURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %q: %w", indexURL, err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		logger.Printf("received %s", resp.Status)
+		return &jsonResponse{notModified: true, header: resp.Header}, nil
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("unexpected response from %q: %s", indexURL, resp.Status)
+	}
+
+	contentType := resp.Header.Get("content-type")
+	if contentType != "application/json" && contentType != "application/vnd+hashicorp.releases-api.v0+json" {
+		return nil, fmt.Errorf("unexpected Content-Type: %q", contentType)
+	}
+
+	logger.Printf("received %s", resp.Status)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jsonResponse{header: resp.Header, body: body}, nil
+}
+
+func unmarshalJSON(body []byte, v interface{}) error {
+	err := json.Unmarshal(body, v)
+	if err != nil {
+		return fmt.Errorf("%w: failed to unmarshal response: %q",
+			err, string(body))
+	}
+	return nil
+}
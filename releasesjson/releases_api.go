@@ -0,0 +1,256 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package releasesjson
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/internal/httpclient"
+)
+
+const (
+	defaultAPIBaseURL = "https://api.releases.hashicorp.com"
+
+	// apiPageLimit is the maximum number of releases
+	// the API returns per page
+	apiPageLimit = 20
+)
+
+const (
+	ReleaseStatusSupported = "supported"
+	ReleaseStatusWithdrawn = "withdrawn"
+)
+
+// ReleaseMetadata represents details of a release
+// which are only available via the releases API
+type ReleaseMetadata struct {
+	// Created and Updated represent when the release
+	// was published and last updated
+	Created time.Time
+	Updated time.Time
+
+	// Status represents the state of the release,
+	// e.g. ReleaseStatusSupported or ReleaseStatusWithdrawn
+	Status        string
+	StatusUpdated time.Time
+
+	// LicenseClass represents the license class
+	// of the release, e.g. oss, enterprise or hcp
+	LicenseClass string
+
+	IsPrerelease bool
+
+	ChangelogURL        string
+	LicenseURL          string
+	ProjectWebsiteURL   string
+	SourceRepositoryURL string
+}
+
+// IsWithdrawn returns true if the release was withdrawn
+// and should not be installed
+func (rm *ReleaseMetadata) IsWithdrawn() bool {
+	return rm.Status == ReleaseStatusWithdrawn
+}
+
+// ReleasesAPI is a client for the paginated JSON releases API (v1)
+// which unlike index.json files provides release metadata
+// such as timestamps, status or license class.
+//
+// Product versions obtained from the API have Metadata populated
+// and builds which refer to the releases site, so they can be
+// downloaded and verified via Downloader as any other version.
+type ReleasesAPI struct {
+	logger  *log.Logger
+	BaseURL string
+
+	// HTTPClient represents the client used for all requests,
+	// defaulting to a client which retries failed requests
+	HTTPClient *http.Client
+}
+
+func NewReleasesAPI() *ReleasesAPI {
+	return &ReleasesAPI{
+		logger:  discardLogger,
+		BaseURL: defaultAPIBaseURL,
+	}
+}
+
+func (ra *ReleasesAPI) SetLogger(logger *log.Logger) {
+	ra.logger = logger
+}
+
+func (ra *ReleasesAPI) log() *log.Logger {
+	if ra.logger == nil {
+		return discardLogger
+	}
+	return ra.logger
+}
+
+func (ra *ReleasesAPI) httpClient() *http.Client {
+	if ra.HTTPClient != nil {
+		return ra.HTTPClient
+	}
+	return httpclient.NewHTTPClient(ra.log())
+}
+
+func (ra *ReleasesAPI) baseURL() string {
+	if ra.BaseURL == "" {
+		return defaultAPIBaseURL
+	}
+	return ra.BaseURL
+}
+
+// ListProductVersions returns all versions of the product,
+// walking through all pages of the API
+func (ra *ReleasesAPI) ListProductVersions(ctx context.Context, productName string) (ProductVersionsMap, error) {
+	pvs := make(ProductVersionsMap, 0)
+
+	after := ""
+	for {
+		q := url.Values{}
+		q.Set("limit", fmt.Sprintf("%d", apiPageLimit))
+		if after != "" {
+			q.Set("after", after)
+		}
+		pageURL := fmt.Sprintf("%s/v1/releases/%s?%s",
+			ra.baseURL(), url.PathEscape(productName), q.Encode())
+		ra.log().Printf("requesting versions from %s", pageURL)
+
+		page := make([]*apiRelease, 0)
+		err := getJSON(ctx, ra.httpClient(), ra.log(), pageURL, &page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to obtain product versions: %w", err)
+		}
+
+		for _, release := range page {
+			pv, err := release.productVersion()
+			if err != nil {
+				// skip unparseable version
+				ra.log().Printf("skipping release %q: %s", release.Version, err)
+				continue
+			}
+			pvs[release.Version] = pv
+		}
+
+		if len(page) < apiPageLimit {
+			break
+		}
+		after = page[len(page)-1].TimestampCreated.Format(time.RFC3339Nano)
+	}
+
+	return pvs, nil
+}
+
+func (ra *ReleasesAPI) GetProductVersion(ctx context.Context, productName string, v *version.Version) (*ProductVersion, error) {
+	releaseURL := fmt.Sprintf("%s/v1/releases/%s/%s",
+		ra.baseURL(),
+		url.PathEscape(productName),
+		url.PathEscape(v.String()))
+	ra.log().Printf("requesting version from %s", releaseURL)
+
+	release := &apiRelease{}
+	err := getJSON(ctx, ra.httpClient(), ra.log(), releaseURL, release)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain product version: %w", err)
+	}
+
+	return release.productVersion()
+}
+
+type apiRelease struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Builds  []struct {
+		OS   string `json:"os"`
+		Arch string `json:"arch"`
+		URL  string `json:"url"`
+	} `json:"builds"`
+	IsPrerelease bool   `json:"is_prerelease"`
+	LicenseClass string `json:"license_class"`
+	Status       struct {
+		State            string    `json:"state"`
+		TimestampUpdated time.Time `json:"timestamp_updated"`
+	} `json:"status"`
+	TimestampCreated     time.Time `json:"timestamp_created"`
+	TimestampUpdated     time.Time `json:"timestamp_updated"`
+	URLChangelog         string    `json:"url_changelog"`
+	URLLicense           string    `json:"url_license"`
+	URLProjectWebsite    string    `json:"url_project_website"`
+	URLShasums           string    `json:"url_shasums"`
+	URLShasumsSignatures []string  `json:"url_shasums_signatures"`
+	URLSourceRepository  string    `json:"url_source_repository"`
+}
+
+// productVersion converts the release into the same
+// representation as obtained from index.json
+func (r *apiRelease) productVersion() (*ProductVersion, error) {
+	v, err := version.NewVersion(r.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	pv := &ProductVersion{
+		Name:    r.Name,
+		Version: v,
+		Builds:  make(ProductBuilds, 0),
+		Metadata: &ReleaseMetadata{
+			Created:             r.TimestampCreated,
+			Updated:             r.TimestampUpdated,
+			Status:              r.Status.State,
+			StatusUpdated:       r.Status.TimestampUpdated,
+			LicenseClass:        r.LicenseClass,
+			IsPrerelease:        r.IsPrerelease,
+			ChangelogURL:        r.URLChangelog,
+			LicenseURL:          r.URLLicense,
+			ProjectWebsiteURL:   r.URLProjectWebsite,
+			SourceRepositoryURL: r.URLSourceRepository,
+		},
+	}
+
+	if r.URLShasums != "" {
+		pv.SHASUMS, err = urlFilename(r.URLShasums)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, sigURL := range r.URLShasumsSignatures {
+		sigFilename, err := urlFilename(sigURL)
+		if err != nil {
+			return nil, err
+		}
+		pv.SHASUMSSigs = append(pv.SHASUMSSigs, sigFilename)
+	}
+
+	for _, b := range r.Builds {
+		filename, err := urlFilename(b.URL)
+		if err != nil {
+			return nil, err
+		}
+		pv.Builds = append(pv.Builds, &ProductBuild{
+			Name:     r.Name,
+			Version:  r.Version,
+			OS:       b.OS,
+			Arch:     b.Arch,
+			Filename: filename,
+			URL:      b.URL,
+		})
+	}
+
+	return pv, nil
+}
+
+func urlFilename(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	return path.Base(u.Path), nil
+}
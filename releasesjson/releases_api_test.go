@@ -0,0 +1,123 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package releasesjson
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/internal/testutil"
+)
+
+func TestReleasesAPI_ListProductVersions(t *testing.T) {
+	srv := testutil.NewReleasesAPITestServer(t, filepath.Join("..", "releases", "testdata", "mock_releases_api"))
+
+	api := &ReleasesAPI{BaseURL: srv.URL}
+	api.SetLogger(testutil.TestLogger())
+
+	pvs, err := api.ListProductVersions(context.Background(), "terraform")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pvs) != 4 {
+		t.Fatalf("expected 4 versions, %d given", len(pvs))
+	}
+
+	pv, ok := pvs["0.14.12"]
+	if !ok {
+		t.Fatal("expected version 0.14.12 to be listed")
+	}
+	if !pv.Metadata.IsWithdrawn() {
+		t.Fatal("expected 0.14.12 to be withdrawn")
+	}
+
+	pv = pvs["0.14.11"]
+	expectedMetadata := &ReleaseMetadata{
+		Created:             time.Date(2021, 4, 26, 12, 0, 0, 0, time.UTC),
+		Updated:             time.Date(2021, 4, 26, 12, 0, 0, 0, time.UTC),
+		Status:              ReleaseStatusSupported,
+		StatusUpdated:       time.Date(2021, 4, 26, 12, 0, 0, 0, time.UTC),
+		LicenseClass:        "oss",
+		ChangelogURL:        "https://github.com/hashicorp/terraform/blob/v0.14.11/CHANGELOG.md",
+		LicenseURL:          "https://github.com/hashicorp/terraform/blob/main/LICENSE",
+		ProjectWebsiteURL:   "https://www.terraform.io",
+		SourceRepositoryURL: "https://github.com/hashicorp/terraform",
+	}
+	if diff := cmp.Diff(expectedMetadata, pv.Metadata); diff != "" {
+		t.Fatalf("unexpected metadata: %s", diff)
+	}
+	if pv.SHASUMS != "terraform_0.14.11_SHA256SUMS" {
+		t.Fatalf("unexpected SHASUMS: %q", pv.SHASUMS)
+	}
+	expectedSigs := []string{
+		"terraform_0.14.11_SHA256SUMS.2FCA0A85.sig",
+		"terraform_0.14.11_SHA256SUMS.sig",
+	}
+	if diff := cmp.Diff(expectedSigs, pv.SHASUMSSigs); diff != "" {
+		t.Fatalf("unexpected signatures: %s", diff)
+	}
+	pb, ok := pv.Builds.FilterBuild("linux", "amd64", "zip")
+	if !ok {
+		t.Fatal("expected linux/amd64 build")
+	}
+	if pb.Filename != "terraform_0.14.11_linux_amd64.zip" {
+		t.Fatalf("unexpected filename: %q", pb.Filename)
+	}
+}
+
+func TestReleasesAPI_ListProductVersions_pagination(t *testing.T) {
+	mockDir := t.TempDir()
+
+	releases := make([]map[string]interface{}, 0)
+	created := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < apiPageLimit+5; i++ {
+		releases = append(releases, map[string]interface{}{
+			"name":              "terraform",
+			"version":           fmt.Sprintf("1.0.%d", i),
+			"timestamp_created": created.Add(time.Duration(i) * time.Hour),
+			"status":            map[string]string{"state": ReleaseStatusSupported},
+		})
+	}
+	b, err := json.Marshal(releases)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(mockDir, "terraform.json"), b, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	api := &ReleasesAPI{BaseURL: testutil.NewReleasesAPITestServer(t, mockDir).URL}
+	pvs, err := api.ListProductVersions(context.Background(), "terraform")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pvs) != len(releases) {
+		t.Fatalf("expected %d versions, %d given", len(releases), len(pvs))
+	}
+}
+
+func TestReleasesAPI_GetProductVersion(t *testing.T) {
+	srv := testutil.NewReleasesAPITestServer(t, filepath.Join("..", "releases", "testdata", "mock_releases_api"))
+
+	api := &ReleasesAPI{BaseURL: srv.URL}
+	pv, err := api.GetProductVersion(context.Background(), "terraform", version.Must(version.NewVersion("0.15.0-rc2")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pv.Version.String() != "0.15.0-rc2" {
+		t.Fatalf("unexpected version: %s", pv.Version)
+	}
+	if !pv.Metadata.IsPrerelease {
+		t.Fatal("expected prerelease")
+	}
+}
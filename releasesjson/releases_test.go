@@ -0,0 +1,101 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package releasesjson
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/internal/testutil"
+)
+
+func TestListProductVersions_includesEnterpriseBuilds(t *testing.T) {
+	testutil.EndToEndTest(t)
+
+	r := NewReleases()
+	r.SetLogger(testutil.TestLogger())
+
+	ctx := context.Background()
+	pVersions, err := r.ListProductVersions(ctx, "consul")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEntVersion := "1.9.8+ent"
+	_, ok := pVersions[testEntVersion]
+	if !ok {
+		t.Fatalf("Failed to find expected Consul Enterprise version %q", testEntVersion)
+	}
+}
+
+func TestGetProductVersion_includesEnterpriseBuild(t *testing.T) {
+	testutil.EndToEndTest(t)
+
+	r := NewReleases()
+	r.SetLogger(testutil.TestLogger())
+
+	ctx := context.Background()
+
+	testEntVersion := version.Must(version.NewVersion("1.9.8+ent"))
+
+	version, err := r.GetProductVersion(ctx, "consul", testEntVersion)
+	if err != nil {
+		t.Fatalf("Unexpected error getting enterprise version %q",
+			testEntVersion.String())
+	}
+
+	if version.Version.String() != testEntVersion.Original() {
+		t.Fatalf("Expected version %q, got %q", testEntVersion.String(), version.Version.String())
+	}
+}
+
+func TestListProducts(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/index.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"terraform": {"name": "terraform", "versions": {}},
+			"consul": {"name": "consul", "versions": {}}
+		}`)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	r := &Releases{BaseURL: srv.URL}
+	r.SetLogger(testutil.TestLogger())
+
+	products, err := r.ListProducts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedProducts := []string{"consul", "terraform"}
+	if diff := cmp.Diff(expectedProducts, products); diff != "" {
+		t.Fatalf("unexpected products: %s", diff)
+	}
+}
+
+func TestListProductVersions_mockServer(t *testing.T) {
+	srv := testutil.NewTestServer(t, filepath.Join("..", "releases", "testdata", "mock_api_tf_0_14_with_prereleases"))
+
+	r := &Releases{BaseURL: srv.URL}
+	pVersions, err := r.ListProductVersions(context.Background(), "terraform")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pv, ok := pVersions["0.14.11"]
+	if !ok {
+		t.Fatal("expected version 0.14.11 to be listed")
+	}
+	if _, ok := pv.Builds.FilterBuild("linux", "amd64", "zip"); !ok {
+		t.Fatal("expected linux/amd64 build of 0.14.11")
+	}
+}
@@ -0,0 +1,772 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package releasesjson
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/transparency-dev/merkle/proof"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"golang.org/x/mod/sumdb/note"
+)
+
+const (
+	defaultSigstoreBundleSuffix = ".sigstore.json"
+	sigstoreBundleMediaType     = "application/vnd.dev.sigstore.bundle.v0.3+json"
+)
+
+// Fulcio certificate extension carrying the OIDC issuer
+var oidIssuer = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+
+// SigstoreVerifier verifies a Sigstore bundle published alongside
+// the checksums (SHA256SUMS) of a product version.
+//
+// Verification is done offline against the supplied trust root,
+// i.e. the signing certificate has to chain up to one of the trusted
+// certificate authorities at the time recorded by one of the trusted
+// transparency logs, which is proven by the signed entry timestamp.
+// The entry also has to be included in the log, which is proven
+// by an inclusion proof against the root hash of a checkpoint
+// signed by the same log.
+//
+// The bundle may contain either a plain signature of the checksums
+// or a DSSE envelope with an in-toto attestation (e.g. SLSA provenance)
+// whose subjects cover the checksums.
+//
+// This deliberately doesn't use sigstore-go, whose dependency tree
+// (Rekor and Fulcio clients, TUF, protobuf and OpenAPI runtimes) would be
+// imposed on every consumer of this library. Instead only the subset
+// used for HashiCorp releases is supported: v0.3 bundles with a single
+// Rekor (v1) entry of the hashedrekord or dsse kind, signed with ECDSA
+// keys. Anything else is rejected. As any change here directly affects
+// which artifacts are trusted, it requires a dedicated security review.
+type SigstoreVerifier struct {
+	// TrustedRoot represents the trust root in JSON format
+	// (as distributed by Sigstore TUF repositories as trusted_root.json)
+	TrustedRoot []byte
+
+	// CertificateIdentity represents the expected SAN
+	// (e.g. URI of the signing workflow or e-mail)
+	CertificateIdentity string
+
+	// CertificateOIDCIssuer represents the expected OIDC issuer
+	// of the signing identity
+	CertificateOIDCIssuer string
+
+	// PredicateType represents the required type of attestation
+	// (e.g. https://slsa.dev/provenance/v1). Plain signatures
+	// of the checksums are rejected when set.
+	PredicateType string
+
+	// BundleSuffix is appended to the name of the checksums file
+	// to get the name of the bundle, defaults to .sigstore.json
+	BundleSuffix string
+
+	Logger *log.Logger
+}
+
+func (sv *SigstoreVerifier) VerifyChecksums(ctx context.Context, rf *ReleaseFiles, checksums []byte) (*ChecksumVerification, error) {
+	if sv.CertificateIdentity == "" || sv.CertificateOIDCIssuer == "" {
+		return nil, fmt.Errorf("certificate identity and OIDC issuer must be provided")
+	}
+
+	tr, err := parseTrustedRoot(sv.TrustedRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	bundleFilename := rf.ProductVersion.SHASUMS + sv.bundleSuffix()
+	body, err := rf.Download(ctx, bundleFilename)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	rawBundle, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sv.verifyBundle(tr, rawBundle, checksums)
+	if err != nil {
+		return nil, fmt.Errorf("unable to verify sigstore bundle %s: %w", bundleFilename, err)
+	}
+
+	sv.log().Printf("sigstore bundle %s is valid (signed by %s)", bundleFilename, sv.CertificateIdentity)
+
+	return &ChecksumVerification{
+		Method:   "sigstore",
+		Identity: sv.CertificateIdentity,
+		Issuer:   sv.CertificateOIDCIssuer,
+	}, nil
+}
+
+func (sv *SigstoreVerifier) bundleSuffix() string {
+	if sv.BundleSuffix != "" {
+		return sv.BundleSuffix
+	}
+	return defaultSigstoreBundleSuffix
+}
+
+func (sv *SigstoreVerifier) log() *log.Logger {
+	if sv.Logger == nil {
+		return discardLogger
+	}
+	return sv.Logger
+}
+
+func (sv *SigstoreVerifier) verifyBundle(tr *trustedRoot, rawBundle, checksums []byte) error {
+	var b sigstoreBundle
+	err := json.Unmarshal(rawBundle, &b)
+	if err != nil {
+		return fmt.Errorf("failed to parse bundle: %w", err)
+	}
+	if b.MediaType != sigstoreBundleMediaType {
+		return fmt.Errorf("unsupported bundle media type %q", b.MediaType)
+	}
+	if len(b.VerificationMaterial.TlogEntries) != 1 {
+		return fmt.Errorf("expected exactly 1 transparency log entry, %d found",
+			len(b.VerificationMaterial.TlogEntries))
+	}
+	entry := b.VerificationMaterial.TlogEntries[0]
+
+	leaf, err := x509.ParseCertificate(b.VerificationMaterial.Certificate.RawBytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse signing certificate: %w", err)
+	}
+
+	var signature []byte
+	var tlogCheck func(*rekorBody) error
+	switch {
+	case b.MessageSignature != nil:
+		if sv.PredicateType != "" {
+			return fmt.Errorf("expected %q attestation, found plain signature", sv.PredicateType)
+		}
+		signature, tlogCheck, err = verifyMessageSignature(leaf, b.MessageSignature, checksums)
+	case b.DSSEEnvelope != nil:
+		signature, tlogCheck, err = sv.verifyDSSEEnvelope(leaf, b.DSSEEnvelope, checksums)
+	default:
+		return fmt.Errorf("bundle contains neither signature nor DSSE envelope")
+	}
+	if err != nil {
+		return err
+	}
+
+	integratedTime, err := tr.verifyTlogEntry(entry)
+	if err != nil {
+		return err
+	}
+	rb, err := entry.rekorBody()
+	if err != nil {
+		return err
+	}
+	err = rb.checkSignature(signature, leaf)
+	if err != nil {
+		return err
+	}
+	err = tlogCheck(rb)
+	if err != nil {
+		return err
+	}
+
+	// The certificate is short-lived, so it is only valid
+	// at the time proven by the transparency log
+	err = tr.verifyCertificate(leaf, integratedTime)
+	if err != nil {
+		return err
+	}
+
+	return sv.checkIdentity(leaf)
+}
+
+func (sv *SigstoreVerifier) checkIdentity(cert *x509.Certificate) error {
+	identities := make([]string, 0)
+	identities = append(identities, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		identities = append(identities, uri.String())
+	}
+
+	found := false
+	for _, identity := range identities {
+		if identity == sv.CertificateIdentity {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("certificate identity %q not found (found: %q)",
+			sv.CertificateIdentity, identities)
+	}
+
+	issuer, err := certificateIssuer(cert)
+	if err != nil {
+		return err
+	}
+	if issuer != sv.CertificateOIDCIssuer {
+		return fmt.Errorf("unexpected certificate OIDC issuer %q (expected: %q)",
+			issuer, sv.CertificateOIDCIssuer)
+	}
+
+	return nil
+}
+
+func (sv *SigstoreVerifier) verifyDSSEEnvelope(leaf *x509.Certificate, env *dsseEnvelope, checksums []byte) ([]byte, func(*rekorBody) error, error) {
+	if env.PayloadType != inTotoPayloadType {
+		return nil, nil, fmt.Errorf("unexpected DSSE payload type %q", env.PayloadType)
+	}
+	if len(env.Signatures) != 1 {
+		return nil, nil, fmt.Errorf("expected exactly 1 DSSE signature, %d found", len(env.Signatures))
+	}
+	signature := env.Signatures[0].Sig
+
+	err := verifySignature(leaf.PublicKey, dssePAE(env.PayloadType, env.Payload), signature)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid DSSE signature: %w", err)
+	}
+
+	var statement inTotoStatement
+	err = json.Unmarshal(env.Payload, &statement)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse in-toto statement: %w", err)
+	}
+	if sv.PredicateType != "" && statement.PredicateType != sv.PredicateType {
+		return nil, nil, fmt.Errorf("unexpected predicate type %q (expected: %q)",
+			statement.PredicateType, sv.PredicateType)
+	}
+	err = statement.checkSubjects(checksums)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	payloadHash := sha256.Sum256(env.Payload)
+	tlogCheck := func(rb *rekorBody) error {
+		if rb.Kind != "dsse" {
+			return fmt.Errorf("unexpected transparency log entry kind %q", rb.Kind)
+		}
+		if rb.Spec.PayloadHash.Value != hex.EncodeToString(payloadHash[:]) {
+			return fmt.Errorf("transparency log entry doesn't match DSSE payload")
+		}
+		return nil
+	}
+
+	return signature, tlogCheck, nil
+}
+
+func verifyMessageSignature(leaf *x509.Certificate, ms *messageSignature, checksums []byte) ([]byte, func(*rekorBody) error, error) {
+	digest := sha256.Sum256(checksums)
+	if ms.MessageDigest.Algorithm != "SHA2_256" {
+		return nil, nil, fmt.Errorf("unsupported digest algorithm %q", ms.MessageDigest.Algorithm)
+	}
+	if !bytes.Equal(ms.MessageDigest.Digest, digest[:]) {
+		return nil, nil, fmt.Errorf("signed digest doesn't match checksums")
+	}
+
+	err := verifySignature(leaf.PublicKey, checksums, ms.Signature)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	tlogCheck := func(rb *rekorBody) error {
+		if rb.Kind != "hashedrekord" {
+			return fmt.Errorf("unexpected transparency log entry kind %q", rb.Kind)
+		}
+		if rb.Spec.Data.Hash.Algorithm != "sha256" ||
+			rb.Spec.Data.Hash.Value != hex.EncodeToString(digest[:]) {
+			return fmt.Errorf("transparency log entry doesn't match checksums")
+		}
+		return nil
+	}
+
+	return ms.Signature, tlogCheck, nil
+}
+
+// verifySignature verifies an ECDSA (P-256 or P-384) signature
+// of the message, which is hashed as mandated by the curve
+func verifySignature(pub crypto.PublicKey, message, signature []byte) error {
+	key, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("unsupported public key type %T", pub)
+	}
+
+	var digest []byte
+	switch key.Curve {
+	case elliptic.P256():
+		sum := sha256.Sum256(message)
+		digest = sum[:]
+	case elliptic.P384():
+		sum := sha512.Sum384(message)
+		digest = sum[:]
+	default:
+		return fmt.Errorf("unsupported curve %s", key.Curve.Params().Name)
+	}
+	if !ecdsa.VerifyASN1(key, digest, signature) {
+		return fmt.Errorf("ECDSA verification failed")
+	}
+	return nil
+}
+
+func certificateIssuer(cert *x509.Certificate) (string, error) {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidIssuer) {
+			var issuer string
+			rest, err := asn1.Unmarshal(ext.Value, &issuer)
+			if err != nil || len(rest) != 0 {
+				return "", fmt.Errorf("invalid OIDC issuer extension")
+			}
+			return issuer, nil
+		}
+	}
+	return "", fmt.Errorf("certificate has no OIDC issuer")
+}
+
+const inTotoPayloadType = "application/vnd.in-toto+json"
+
+// dssePAE returns the pre-authentication encoding of the DSSE payload
+func dssePAE(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s",
+		len(payloadType), payloadType, len(payload), payload))
+}
+
+type sigstoreBundle struct {
+	MediaType            string               `json:"mediaType"`
+	VerificationMaterial verificationMaterial `json:"verificationMaterial"`
+	MessageSignature     *messageSignature    `json:"messageSignature"`
+	DSSEEnvelope         *dsseEnvelope        `json:"dsseEnvelope"`
+}
+
+type verificationMaterial struct {
+	Certificate rawBytes     `json:"certificate"`
+	TlogEntries []*tlogEntry `json:"tlogEntries"`
+}
+
+type rawBytes struct {
+	RawBytes []byte `json:"rawBytes"`
+}
+
+type certChain struct {
+	Certificates []rawBytes `json:"certificates"`
+}
+
+type messageSignature struct {
+	MessageDigest struct {
+		Algorithm string `json:"algorithm"`
+		Digest    []byte `json:"digest"`
+	} `json:"messageDigest"`
+	Signature []byte `json:"signature"`
+}
+
+type dsseEnvelope struct {
+	Payload     []byte `json:"payload"`
+	PayloadType string `json:"payloadType"`
+	Signatures  []struct {
+		Sig []byte `json:"sig"`
+	} `json:"signatures"`
+}
+
+type inTotoStatement struct {
+	Type          string `json:"_type"`
+	PredicateType string `json:"predicateType"`
+	Subject       []struct {
+		Name   string            `json:"name"`
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+}
+
+// checkSubjects checks that the attestation covers either
+// the checksums file itself or all of the files listed in it
+func (s *inTotoStatement) checkSubjects(checksums []byte) error {
+	digests := make(map[string]bool, 0)
+	for _, subject := range s.Subject {
+		if d, ok := subject.Digest["sha256"]; ok {
+			digests[d] = true
+		}
+	}
+
+	checksumsDigest := sha256.Sum256(checksums)
+	if digests[hex.EncodeToString(checksumsDigest[:])] {
+		return nil
+	}
+
+	fileMap, err := fileMapFromChecksums(checksums)
+	if err != nil {
+		return err
+	}
+	if len(fileMap) == 0 {
+		return fmt.Errorf("attestation doesn't cover checksums")
+	}
+	for filename, sum := range fileMap {
+		if !digests[sum.String()] {
+			return fmt.Errorf("attestation doesn't cover %s", filename)
+		}
+	}
+
+	return nil
+}
+
+type tlogEntry struct {
+	LogIndex string `json:"logIndex"`
+	LogID    struct {
+		KeyID []byte `json:"keyId"`
+	} `json:"logId"`
+	IntegratedTime   string `json:"integratedTime"`
+	InclusionPromise *struct {
+		SignedEntryTimestamp []byte `json:"signedEntryTimestamp"`
+	} `json:"inclusionPromise"`
+	InclusionProof    *inclusionProof `json:"inclusionProof"`
+	CanonicalizedBody []byte          `json:"canonicalizedBody"`
+}
+
+type inclusionProof struct {
+	LogIndex   string   `json:"logIndex"`
+	RootHash   []byte   `json:"rootHash"`
+	TreeSize   string   `json:"treeSize"`
+	Hashes     [][]byte `json:"hashes"`
+	Checkpoint struct {
+		Envelope string `json:"envelope"`
+	} `json:"checkpoint"`
+}
+
+func (te *tlogEntry) rekorBody() (*rekorBody, error) {
+	var rb rekorBody
+	err := json.Unmarshal(te.CanonicalizedBody, &rb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transparency log entry: %w", err)
+	}
+	return &rb, nil
+}
+
+// rekorBody represents the fields of hashedrekord
+// and dsse entries relevant for verification
+type rekorBody struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		// hashedrekord
+		Data struct {
+			Hash struct {
+				Algorithm string `json:"algorithm"`
+				Value     string `json:"value"`
+			} `json:"hash"`
+		} `json:"data"`
+		Signature struct {
+			Content   []byte `json:"content"`
+			PublicKey struct {
+				Content []byte `json:"content"`
+			} `json:"publicKey"`
+		} `json:"signature"`
+
+		// dsse
+		PayloadHash struct {
+			Algorithm string `json:"algorithm"`
+			Value     string `json:"value"`
+		} `json:"payloadHash"`
+		Signatures []struct {
+			Signature []byte `json:"signature"`
+			Verifier  []byte `json:"verifier"`
+		} `json:"signatures"`
+	} `json:"spec"`
+}
+
+// checkSignature checks that the entry records
+// the given signature made by the given certificate
+func (rb *rekorBody) checkSignature(signature []byte, cert *x509.Certificate) error {
+	certMatches := func(pemBytes []byte) bool {
+		block, _ := pem.Decode(pemBytes)
+		return block != nil && bytes.Equal(block.Bytes, cert.Raw)
+	}
+
+	switch rb.Kind {
+	case "hashedrekord":
+		if bytes.Equal(rb.Spec.Signature.Content, signature) &&
+			certMatches(rb.Spec.Signature.PublicKey.Content) {
+			return nil
+		}
+	case "dsse":
+		if len(rb.Spec.Signatures) == 1 &&
+			bytes.Equal(rb.Spec.Signatures[0].Signature, signature) &&
+			certMatches(rb.Spec.Signatures[0].Verifier) {
+			return nil
+		}
+	default:
+		return fmt.Errorf("unsupported transparency log entry kind %q", rb.Kind)
+	}
+
+	return fmt.Errorf("transparency log entry doesn't match signature")
+}
+
+type trustedRoot struct {
+	Tlogs []struct {
+		PublicKey struct {
+			RawBytes []byte    `json:"rawBytes"`
+			ValidFor *validFor `json:"validFor"`
+		} `json:"publicKey"`
+		LogID struct {
+			KeyID []byte `json:"keyId"`
+		} `json:"logId"`
+	} `json:"tlogs"`
+	CertificateAuthorities []struct {
+		CertChain certChain `json:"certChain"`
+		ValidFor  *validFor `json:"validFor"`
+	} `json:"certificateAuthorities"`
+}
+
+type validFor struct {
+	Start *time.Time `json:"start"`
+	End   *time.Time `json:"end"`
+}
+
+func (vf *validFor) contains(t time.Time) bool {
+	if vf == nil {
+		return true
+	}
+	if vf.Start != nil && t.Before(*vf.Start) {
+		return false
+	}
+	if vf.End != nil && t.After(*vf.End) {
+		return false
+	}
+	return true
+}
+
+func parseTrustedRoot(b []byte) (*trustedRoot, error) {
+	if len(b) == 0 {
+		return nil, fmt.Errorf("no trusted root provided")
+	}
+
+	var tr trustedRoot
+	err := json.Unmarshal(b, &tr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse trusted root: %w", err)
+	}
+	if len(tr.Tlogs) == 0 {
+		return nil, fmt.Errorf("trusted root contains no transparency logs")
+	}
+	if len(tr.CertificateAuthorities) == 0 {
+		return nil, fmt.Errorf("trusted root contains no certificate authorities")
+	}
+
+	return &tr, nil
+}
+
+// verifyTlogEntry verifies the signed entry timestamp and the inclusion
+// proof of the entry and returns the time at which the entry
+// was integrated into the log
+func (tr *trustedRoot) verifyTlogEntry(entry *tlogEntry) (time.Time, error) {
+	if entry.InclusionPromise == nil {
+		return time.Time{}, fmt.Errorf("transparency log entry has no inclusion promise")
+	}
+
+	logIndex, err := strconv.ParseInt(entry.LogIndex, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid log index: %w", err)
+	}
+	unixTime, err := strconv.ParseInt(entry.IntegratedTime, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid integrated time: %w", err)
+	}
+	integratedTime := time.Unix(unixTime, 0)
+
+	for _, tlog := range tr.Tlogs {
+		if !bytes.Equal(tlog.LogID.KeyID, entry.LogID.KeyID) {
+			continue
+		}
+		if !tlog.PublicKey.ValidFor.contains(integratedTime) {
+			return time.Time{}, fmt.Errorf("transparency log key is not valid at %s", integratedTime)
+		}
+
+		pub, err := x509.ParsePKIXPublicKey(tlog.PublicKey.RawBytes)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse transparency log key: %w", err)
+		}
+
+		// The payload is canonical JSON, i.e. with keys sorted
+		payload, err := json.Marshal(struct {
+			Body           string `json:"body"`
+			IntegratedTime int64  `json:"integratedTime"`
+			LogID          string `json:"logID"`
+			LogIndex       int64  `json:"logIndex"`
+		}{
+			Body:           base64.StdEncoding.EncodeToString(entry.CanonicalizedBody),
+			IntegratedTime: unixTime,
+			LogID:          hex.EncodeToString(entry.LogID.KeyID),
+			LogIndex:       logIndex,
+		})
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		err = verifySignature(pub, payload, entry.InclusionPromise.SignedEntryTimestamp)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid signed entry timestamp: %w", err)
+		}
+
+		// The signed entry timestamp is only a promise of inclusion,
+		// so we also check that the log actually contains the entry
+		err = verifyInclusionProof(entry, pub, tlog.PublicKey.RawBytes)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return integratedTime, nil
+	}
+
+	return time.Time{}, fmt.Errorf("unknown transparency log %x", entry.LogID.KeyID)
+}
+
+// verifyCertificate verifies that the certificate chains up
+// to any of the trusted certificate authorities at the given time
+func (tr *trustedRoot) verifyCertificate(cert *x509.Certificate, t time.Time) error {
+	var errs *multierror.Error
+	for _, ca := range tr.CertificateAuthorities {
+		if !ca.ValidFor.contains(t) {
+			continue
+		}
+
+		roots := x509.NewCertPool()
+		intermediates := x509.NewCertPool()
+		for _, raw := range ca.CertChain.Certificates {
+			caCert, err := x509.ParseCertificate(raw.RawBytes)
+			if err != nil {
+				return fmt.Errorf("failed to parse CA certificate: %w", err)
+			}
+			if bytes.Equal(caCert.RawIssuer, caCert.RawSubject) {
+				roots.AddCert(caCert)
+			} else {
+				intermediates.AddCert(caCert)
+			}
+		}
+
+		_, err := cert.Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			CurrentTime:   t,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		})
+		if err == nil {
+			return nil
+		}
+		errs = multierror.Append(errs, err)
+	}
+
+	if errs == nil {
+		return fmt.Errorf("no certificate authority is valid at %s", t)
+	}
+	return fmt.Errorf("untrusted signing certificate: %w", errs.ErrorOrNil())
+}
+
+// verifyInclusionProof verifies that the entry is included in the log
+// whose root hash is committed to by a checkpoint signed by the given key
+func verifyInclusionProof(entry *tlogEntry, pub crypto.PublicKey, rawPub []byte) error {
+	ip := entry.InclusionProof
+	if ip == nil {
+		return fmt.Errorf("transparency log entry has no inclusion proof")
+	}
+
+	index, err := strconv.ParseUint(ip.LogIndex, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid inclusion proof log index: %w", err)
+	}
+	treeSize, err := strconv.ParseUint(ip.TreeSize, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid inclusion proof tree size: %w", err)
+	}
+
+	leafHash := rfc6962.DefaultHasher.HashLeaf(entry.CanonicalizedBody)
+	err = proof.VerifyInclusion(rfc6962.DefaultHasher, index, treeSize, leafHash, ip.Hashes, ip.RootHash)
+	if err != nil {
+		return fmt.Errorf("invalid inclusion proof: %w", err)
+	}
+
+	cp, err := openCheckpoint(ip.Checkpoint.Envelope, pub, rawPub)
+	if err != nil {
+		return err
+	}
+	if cp.treeSize != treeSize || !bytes.Equal(cp.rootHash, ip.RootHash) {
+		return fmt.Errorf("checkpoint (size %d) doesn't match inclusion proof (size %d)",
+			cp.treeSize, treeSize)
+	}
+
+	return nil
+}
+
+// checkpoint represents the state of a transparency log
+// as committed to by the log
+type checkpoint struct {
+	treeSize uint64
+	rootHash []byte
+}
+
+// openCheckpoint verifies the signature of the checkpoint
+// (a signed note) and parses it
+func openCheckpoint(envelope string, pub crypto.PublicKey, rawPub []byte) (*checkpoint, error) {
+	keyHash := sha256.Sum256(rawPub)
+	n, err := note.Open([]byte(envelope), &checkpointVerifier{
+		pub:     pub,
+		keyHash: binary.BigEndian.Uint32(keyHash[:4]),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkpoint: %w", err)
+	}
+
+	// origin, tree size and root hash, optionally followed by extension lines
+	lines := strings.Split(n.Text, "\n")
+	if len(lines) < 4 {
+		return nil, fmt.Errorf("invalid checkpoint: expected at least 3 lines")
+	}
+	treeSize, err := strconv.ParseUint(lines[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkpoint tree size: %w", err)
+	}
+	rootHash, err := base64.StdEncoding.DecodeString(lines[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkpoint root hash: %w", err)
+	}
+
+	return &checkpoint{
+		treeSize: treeSize,
+		rootHash: rootHash,
+	}, nil
+}
+
+// checkpointVerifier verifies signatures made by the transparency log key,
+// which is identified by the key hash (first 4 bytes of the SHA-256
+// of the key), regardless of the signer name
+type checkpointVerifier struct {
+	name    string
+	keyHash uint32
+	pub     crypto.PublicKey
+}
+
+func (cv *checkpointVerifier) Verifier(name string, hash uint32) (note.Verifier, error) {
+	if hash != cv.keyHash {
+		return nil, &note.UnknownVerifierError{Name: name, KeyHash: hash}
+	}
+	return &checkpointVerifier{name: name, keyHash: hash, pub: cv.pub}, nil
+}
+
+func (cv *checkpointVerifier) Name() string    { return cv.name }
+func (cv *checkpointVerifier) KeyHash() uint32 { return cv.keyHash }
+
+func (cv *checkpointVerifier) Verify(msg, sig []byte) bool {
+	return verifySignature(cv.pub, msg, sig) == nil
+}
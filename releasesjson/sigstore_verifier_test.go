@@ -0,0 +1,673 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package releasesjson
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/internal/testutil"
+	"github.com/transparency-dev/merkle/rfc6962"
+)
+
+const (
+	testIdentity = "https://github.com/hashicorp/example/.github/workflows/release.yml@refs/heads/main"
+	testIssuer   = "https://token.actions.githubusercontent.com"
+
+	testChecksums = "6c74ec6d2ba6e6b4ba5e4b3a6b27ee8f7a6a10c9ad2d8e44ee0a1e4d6e9a6bfd  terraform_0.14.11_darwin_amd64.zip\n" +
+		"e6c14be11c65ab0ba1f9b7cbf0b3dc0e6fbdcd77cf8ba5c30be2cc0fd1fdd5ab  terraform_0.14.11_linux_amd64.zip\n"
+)
+
+func TestSigstoreVerifier_verifyBundle(t *testing.T) {
+	f := newSigstoreFixture(t, testIdentity, testIssuer)
+	checksums := []byte(testChecksums)
+
+	checksumsDigest := sha256.Sum256(checksums)
+	fileMap, err := fileMapFromChecksums(checksums)
+	if err != nil {
+		t.Fatal(err)
+	}
+	allFiles := make(map[string]string, 0)
+	for filename, sum := range fileMap {
+		allFiles[filename] = sum.String()
+	}
+
+	otherLog := newSigstoreFixture(t, testIdentity, testIssuer)
+	otherLog.caKey, otherLog.caCert = f.caKey, f.caCert
+
+	validBundle := f.messageBundle(t, checksums, time.Now())
+
+	testCases := []struct {
+		name          string
+		verifier      *SigstoreVerifier
+		bundle        []byte
+		checksums     []byte
+		expectedError string
+	}{
+		{
+			name:      "message signature",
+			verifier:  f.verifier(""),
+			bundle:    f.messageBundle(t, checksums, time.Now()),
+			checksums: checksums,
+		},
+		{
+			name:     "SLSA provenance of checksums",
+			verifier: f.verifier("https://slsa.dev/provenance/v1"),
+			bundle: f.dsseBundle(t, "https://slsa.dev/provenance/v1", map[string]string{
+				"terraform_0.14.11_SHA256SUMS": hex.EncodeToString(checksumsDigest[:]),
+			}),
+			checksums: checksums,
+		},
+		{
+			name:      "SLSA provenance of all archives",
+			verifier:  f.verifier("https://slsa.dev/provenance/v1"),
+			bundle:    f.dsseBundle(t, "https://slsa.dev/provenance/v1", allFiles),
+			checksums: checksums,
+		},
+		{
+			name:     "SLSA provenance missing archive",
+			verifier: f.verifier("https://slsa.dev/provenance/v1"),
+			bundle: f.dsseBundle(t, "https://slsa.dev/provenance/v1", map[string]string{
+				"terraform_0.14.11_linux_amd64.zip": allFiles["terraform_0.14.11_linux_amd64.zip"],
+			}),
+			checksums:     checksums,
+			expectedError: "attestation doesn't cover terraform_0.14.11_darwin_amd64.zip",
+		},
+		{
+			name:          "unexpected predicate type",
+			verifier:      f.verifier("https://slsa.dev/provenance/v1"),
+			bundle:        f.dsseBundle(t, "https://example.com/predicate/v1", allFiles),
+			checksums:     checksums,
+			expectedError: "unexpected predicate type",
+		},
+		{
+			name:          "plain signature when attestation is required",
+			verifier:      f.verifier("https://slsa.dev/provenance/v1"),
+			bundle:        f.messageBundle(t, checksums, time.Now()),
+			checksums:     checksums,
+			expectedError: "found plain signature",
+		},
+		{
+			name:          "tampered checksums",
+			verifier:      f.verifier(""),
+			bundle:        f.messageBundle(t, checksums, time.Now()),
+			checksums:     []byte(strings.ToUpper(testChecksums)),
+			expectedError: "signed digest doesn't match checksums",
+		},
+		{
+			name: "unexpected identity",
+			verifier: &SigstoreVerifier{
+				TrustedRoot:           f.trustedRoot,
+				CertificateIdentity:   "https://github.com/example/example/.github/workflows/release.yml@refs/heads/main",
+				CertificateOIDCIssuer: testIssuer,
+			},
+			bundle:        f.messageBundle(t, checksums, time.Now()),
+			checksums:     checksums,
+			expectedError: "certificate identity",
+		},
+		{
+			name: "unexpected issuer",
+			verifier: &SigstoreVerifier{
+				TrustedRoot:           f.trustedRoot,
+				CertificateIdentity:   testIdentity,
+				CertificateOIDCIssuer: "https://accounts.google.com",
+			},
+			bundle:        f.messageBundle(t, checksums, time.Now()),
+			checksums:     checksums,
+			expectedError: "unexpected certificate OIDC issuer",
+		},
+		{
+			name:          "untrusted transparency log",
+			verifier:      f.verifier(""),
+			bundle:        otherLog.messageBundle(t, checksums, time.Now()),
+			checksums:     checksums,
+			expectedError: "unknown transparency log",
+		},
+		{
+			name:     "missing inclusion proof",
+			verifier: f.verifier(""),
+			bundle: withTlogEntry(t, validBundle, func(entry map[string]interface{}) {
+				delete(entry, "inclusionProof")
+			}),
+			checksums:     checksums,
+			expectedError: "transparency log entry has no inclusion proof",
+		},
+		{
+			name:     "tampered inclusion proof",
+			verifier: f.verifier(""),
+			bundle: withTlogEntry(t, validBundle, func(entry map[string]interface{}) {
+				ip := entry["inclusionProof"].(map[string]interface{})
+				ip["hashes"].([]interface{})[0] = make([]byte, sha256.Size)
+			}),
+			checksums:     checksums,
+			expectedError: "invalid inclusion proof",
+		},
+		{
+			name:     "entry not included in log",
+			verifier: f.verifier(""),
+			bundle: withTlogEntry(t, validBundle, func(entry map[string]interface{}) {
+				entry["inclusionProof"] = f.inclusionProof(t, []byte("another entry"))
+			}),
+			checksums:     checksums,
+			expectedError: "invalid inclusion proof",
+		},
+		{
+			name:     "checkpoint of different tree",
+			verifier: f.verifier(""),
+			bundle: withTlogEntry(t, validBundle, func(entry map[string]interface{}) {
+				ip := entry["inclusionProof"].(map[string]interface{})
+				rootHash, err := base64.StdEncoding.DecodeString(ip["rootHash"].(string))
+				if err != nil {
+					t.Fatal(err)
+				}
+				ip["checkpoint"] = map[string]interface{}{
+					"envelope": f.checkpoint(t, f.rekorKey, 8, rootHash),
+				}
+			}),
+			checksums:     checksums,
+			expectedError: "checkpoint (size 8) doesn't match inclusion proof (size 7)",
+		},
+		{
+			name:     "tampered checkpoint",
+			verifier: f.verifier(""),
+			bundle: withTlogEntry(t, validBundle, func(entry map[string]interface{}) {
+				cp := entry["inclusionProof"].(map[string]interface{})["checkpoint"].(map[string]interface{})
+				cp["envelope"] = strings.Replace(cp["envelope"].(string), "\n7\n", "\n8\n", 1)
+			}),
+			checksums:     checksums,
+			expectedError: "invalid checkpoint",
+		},
+		{
+			name:     "checkpoint signed by untrusted log",
+			verifier: f.verifier(""),
+			bundle: withTlogEntry(t, validBundle, func(entry map[string]interface{}) {
+				ip := entry["inclusionProof"].(map[string]interface{})
+				rootHash, err := base64.StdEncoding.DecodeString(ip["rootHash"].(string))
+				if err != nil {
+					t.Fatal(err)
+				}
+				ip["checkpoint"] = map[string]interface{}{
+					"envelope": f.checkpoint(t, otherLog.rekorKey, 7, rootHash),
+				}
+			}),
+			checksums:     checksums,
+			expectedError: "invalid checkpoint",
+		},
+		{
+			name:     "tampered signed entry timestamp",
+			verifier: f.verifier(""),
+			bundle: withTlogEntry(t, validBundle, func(entry map[string]interface{}) {
+				entry["integratedTime"] = strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+			}),
+			checksums:     checksums,
+			expectedError: "invalid signed entry timestamp",
+		},
+		{
+			name:          "malformed bundle",
+			verifier:      f.verifier(""),
+			bundle:        validBundle[:len(validBundle)/2],
+			checksums:     checksums,
+			expectedError: "failed to parse bundle",
+		},
+		{
+			name:     "unsupported bundle version",
+			verifier: f.verifier(""),
+			bundle: withBundle(t, validBundle, func(b map[string]interface{}) {
+				b["mediaType"] = "application/vnd.dev.sigstore.bundle+json;version=0.2"
+			}),
+			checksums:     checksums,
+			expectedError: "unsupported bundle media type",
+		},
+		{
+			name:     "multiple transparency log entries",
+			verifier: f.verifier(""),
+			bundle: withBundle(t, validBundle, func(b map[string]interface{}) {
+				vm := b["verificationMaterial"].(map[string]interface{})
+				entries := vm["tlogEntries"].([]interface{})
+				vm["tlogEntries"] = append(entries, entries[0])
+			}),
+			checksums:     checksums,
+			expectedError: "expected exactly 1 transparency log entry, 2 found",
+		},
+		{
+			name:          "certificate expired at integrated time",
+			verifier:      f.verifier(""),
+			bundle:        f.messageBundle(t, checksums, time.Now().Add(time.Hour)),
+			checksums:     checksums,
+			expectedError: "untrusted signing certificate",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tr, err := parseTrustedRoot(tc.verifier.TrustedRoot)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = tc.verifier.verifyBundle(tr, tc.bundle, tc.checksums)
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q", tc.expectedError)
+			}
+			if !strings.Contains(err.Error(), tc.expectedError) {
+				t.Fatalf("expected error containing %q, got: %s", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestSigstoreVerifier_VerifyChecksums(t *testing.T) {
+	f := newSigstoreFixture(t, testIdentity, testIssuer)
+	checksums := []byte(testChecksums)
+	bundle := f.messageBundle(t, checksums, time.Now())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/terraform/0.14.11/terraform_0.14.11_SHA256SUMS.sigstore.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(bundle)
+	}))
+	t.Cleanup(srv.Close)
+
+	rf := &ReleaseFiles{
+		ProductVersion: &ProductVersion{
+			Name:    "terraform",
+			Version: version.Must(version.NewVersion("0.14.11")),
+			SHASUMS: "terraform_0.14.11_SHA256SUMS",
+		},
+		BaseURL:    srv.URL,
+		HTTPClient: srv.Client(),
+		logger:     testutil.TestLogger(),
+	}
+
+	sv := f.verifier("")
+	sv.Logger = testutil.TestLogger()
+	verification, err := sv.VerifyChecksums(context.Background(), rf, checksums)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if verification.Method != "sigstore" {
+		t.Fatalf("unexpected method: %q", verification.Method)
+	}
+	if verification.Identity != testIdentity {
+		t.Fatalf("unexpected identity: %q", verification.Identity)
+	}
+	if verification.Issuer != testIssuer {
+		t.Fatalf("unexpected issuer: %q", verification.Issuer)
+	}
+}
+
+// sigstoreFixture represents a self-contained Sigstore instance,
+// i.e. certificate authority, transparency log and a signing certificate
+type sigstoreFixture struct {
+	caKey    *ecdsa.PrivateKey
+	caCert   *x509.Certificate
+	leafKey  *ecdsa.PrivateKey
+	leafCert *x509.Certificate
+	rekorKey *ecdsa.PrivateKey
+	logID    []byte
+
+	trustedRoot []byte
+}
+
+func newSigstoreFixture(t *testing.T, identity, issuer string) *sigstoreFixture {
+	f := &sigstoreFixture{
+		caKey:    generateTestKey(t),
+		leafKey:  generateTestKey(t),
+		rekorKey: generateTestKey(t),
+	}
+
+	now := time.Now()
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "sigstore"},
+		NotBefore:             now.Add(-24 * time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &f.caKey.PublicKey, f.caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.caCert, err = x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identityURI, err := url.Parse(identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuerExt, err := asn1.MarshalWithParams(issuer, "utf8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     now.Add(10 * time.Minute),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		URIs:         []*url.URL{identityURI},
+		ExtraExtensions: []pkix.Extension{
+			{Id: oidIssuer, Value: issuerExt},
+		},
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, f.caCert, &f.leafKey.PublicKey, f.caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.leafCert, err = x509.ParseCertificate(leafDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rekorPub, err := x509.MarshalPKIXPublicKey(&f.rekorKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logID := sha256.Sum256(rekorPub)
+	f.logID = logID[:]
+
+	f.trustedRoot = marshalJSON(t, map[string]interface{}{
+		"mediaType": "application/vnd.dev.sigstore.trustedroot+json;version=0.1",
+		"tlogs": []interface{}{
+			map[string]interface{}{
+				"publicKey": map[string]interface{}{
+					"rawBytes": rekorPub,
+					"validFor": map[string]interface{}{"start": now.Add(-24 * time.Hour)},
+				},
+				"logId": map[string]interface{}{"keyId": f.logID},
+			},
+		},
+		"certificateAuthorities": []interface{}{
+			map[string]interface{}{
+				"certChain": map[string]interface{}{
+					"certificates": []interface{}{
+						map[string]interface{}{"rawBytes": caDER},
+					},
+				},
+			},
+		},
+	})
+
+	return f
+}
+
+func (f *sigstoreFixture) verifier(predicateType string) *SigstoreVerifier {
+	return &SigstoreVerifier{
+		TrustedRoot:           f.trustedRoot,
+		CertificateIdentity:   testIdentity,
+		CertificateOIDCIssuer: testIssuer,
+		PredicateType:         predicateType,
+	}
+}
+
+func (f *sigstoreFixture) messageBundle(t *testing.T, checksums []byte, integratedTime time.Time) []byte {
+	digest := sha256.Sum256(checksums)
+	signature, err := ecdsa.SignASN1(rand.Reader, f.leafKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := marshalJSON(t, map[string]interface{}{
+		"apiVersion": "0.0.1",
+		"kind":       "hashedrekord",
+		"spec": map[string]interface{}{
+			"data": map[string]interface{}{
+				"hash": map[string]interface{}{
+					"algorithm": "sha256",
+					"value":     hex.EncodeToString(digest[:]),
+				},
+			},
+			"signature": map[string]interface{}{
+				"content": signature,
+				"publicKey": map[string]interface{}{
+					"content": f.leafCertPEM(),
+				},
+			},
+		},
+	})
+
+	return marshalJSON(t, map[string]interface{}{
+		"mediaType":            "application/vnd.dev.sigstore.bundle.v0.3+json",
+		"verificationMaterial": f.verificationMaterial(t, body, integratedTime),
+		"messageSignature": map[string]interface{}{
+			"messageDigest": map[string]interface{}{
+				"algorithm": "SHA2_256",
+				"digest":    digest[:],
+			},
+			"signature": signature,
+		},
+	})
+}
+
+func (f *sigstoreFixture) dsseBundle(t *testing.T, predicateType string, subjects map[string]string) []byte {
+	subject := make([]interface{}, 0)
+	for name, digest := range subjects {
+		subject = append(subject, map[string]interface{}{
+			"name":   name,
+			"digest": map[string]string{"sha256": digest},
+		})
+	}
+	payload := marshalJSON(t, map[string]interface{}{
+		"_type":         "https://in-toto.io/Statement/v1",
+		"subject":       subject,
+		"predicateType": predicateType,
+		"predicate":     map[string]interface{}{},
+	})
+
+	digest := sha256.Sum256(dssePAE(inTotoPayloadType, payload))
+	signature, err := ecdsa.SignASN1(rand.Reader, f.leafKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payloadHash := sha256.Sum256(payload)
+	body := marshalJSON(t, map[string]interface{}{
+		"apiVersion": "0.0.1",
+		"kind":       "dsse",
+		"spec": map[string]interface{}{
+			"payloadHash": map[string]interface{}{
+				"algorithm": "sha256",
+				"value":     hex.EncodeToString(payloadHash[:]),
+			},
+			"signatures": []interface{}{
+				map[string]interface{}{
+					"signature": signature,
+					"verifier":  f.leafCertPEM(),
+				},
+			},
+		},
+	})
+
+	return marshalJSON(t, map[string]interface{}{
+		"mediaType":            "application/vnd.dev.sigstore.bundle.v0.3+json",
+		"verificationMaterial": f.verificationMaterial(t, body, time.Now()),
+		"dsseEnvelope": map[string]interface{}{
+			"payload":     payload,
+			"payloadType": inTotoPayloadType,
+			"signatures": []interface{}{
+				map[string]interface{}{"sig": signature},
+			},
+		},
+	})
+}
+
+func (f *sigstoreFixture) verificationMaterial(t *testing.T, body []byte, integratedTime time.Time) map[string]interface{} {
+	logIndex := int64(42)
+	set := marshalJSON(t, map[string]interface{}{
+		"body":           base64.StdEncoding.EncodeToString(body),
+		"integratedTime": integratedTime.Unix(),
+		"logID":          hex.EncodeToString(f.logID),
+		"logIndex":       logIndex,
+	})
+	setDigest := sha256.Sum256(set)
+	setSignature, err := ecdsa.SignASN1(rand.Reader, f.rekorKey, setDigest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return map[string]interface{}{
+		"certificate": map[string]interface{}{"rawBytes": f.leafCert.Raw},
+		"tlogEntries": []interface{}{
+			map[string]interface{}{
+				"logIndex":       strconv.FormatInt(logIndex, 10),
+				"logId":          map[string]interface{}{"keyId": f.logID},
+				"kindVersion":    map[string]interface{}{"kind": "hashedrekord", "version": "0.0.1"},
+				"integratedTime": strconv.FormatInt(integratedTime.Unix(), 10),
+				"inclusionPromise": map[string]interface{}{
+					"signedEntryTimestamp": setSignature,
+				},
+				"inclusionProof":    f.inclusionProof(t, body),
+				"canonicalizedBody": body,
+			},
+		},
+	}
+}
+
+// inclusionProof returns the proof of inclusion of the entry body
+// in a small log containing a few other entries
+func (f *sigstoreFixture) inclusionProof(t *testing.T, body []byte) map[string]interface{} {
+	index := 5
+	leaves := make([][]byte, 7)
+	for i := range leaves {
+		leaves[i] = rfc6962.DefaultHasher.HashLeaf([]byte(fmt.Sprintf("other entry %d", i)))
+	}
+	leaves[index] = rfc6962.DefaultHasher.HashLeaf(body)
+	rootHash := merkleTreeHash(leaves)
+
+	return map[string]interface{}{
+		"logIndex": strconv.Itoa(index),
+		"rootHash": rootHash,
+		"treeSize": strconv.Itoa(len(leaves)),
+		"hashes":   merkleInclusionPath(index, leaves),
+		"checkpoint": map[string]interface{}{
+			"envelope": f.checkpoint(t, f.rekorKey, uint64(len(leaves)), rootHash),
+		},
+	}
+}
+
+// checkpoint returns a checkpoint of the log signed by the given key
+func (f *sigstoreFixture) checkpoint(t *testing.T, key *ecdsa.PrivateKey, treeSize uint64, rootHash []byte) string {
+	text := fmt.Sprintf("rekor.example.com - 1234\n%d\n%s\n",
+		treeSize, base64.StdEncoding.EncodeToString(rootHash))
+
+	digest := sha256.Sum256([]byte(text))
+	signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyHash := sha256.Sum256(pub)
+
+	return fmt.Sprintf("%s\n\u2014 rekor.example.com %s\n", text,
+		base64.StdEncoding.EncodeToString(append(keyHash[:4], signature...)))
+}
+
+// merkleTreeHash returns the RFC 6962 hash of the tree of the given leaf hashes
+func merkleTreeHash(leaves [][]byte) []byte {
+	if len(leaves) == 1 {
+		return leaves[0]
+	}
+	k := splitPoint(len(leaves))
+	return rfc6962.DefaultHasher.HashChildren(merkleTreeHash(leaves[:k]), merkleTreeHash(leaves[k:]))
+}
+
+// merkleInclusionPath returns the RFC 6962 audit path
+// of the leaf at the given index
+func merkleInclusionPath(index int, leaves [][]byte) [][]byte {
+	if len(leaves) == 1 {
+		return [][]byte{}
+	}
+	k := splitPoint(len(leaves))
+	if index < k {
+		return append(merkleInclusionPath(index, leaves[:k]), merkleTreeHash(leaves[k:]))
+	}
+	return append(merkleInclusionPath(index-k, leaves[k:]), merkleTreeHash(leaves[:k]))
+}
+
+// splitPoint returns the largest power of two smaller than n
+func splitPoint(n int) int {
+	k := 1
+	for k<<1 < n {
+		k <<= 1
+	}
+	return k
+}
+
+// withBundle returns the bundle modified
+func withBundle(t *testing.T, bundle []byte, modify func(b map[string]interface{})) []byte {
+	var b map[string]interface{}
+	err := json.Unmarshal(bundle, &b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	modify(b)
+	return marshalJSON(t, b)
+}
+
+// withTlogEntry returns the bundle with its transparency log entry modified
+func withTlogEntry(t *testing.T, bundle []byte, modify func(entry map[string]interface{})) []byte {
+	return withBundle(t, bundle, func(b map[string]interface{}) {
+		vm := b["verificationMaterial"].(map[string]interface{})
+		modify(vm["tlogEntries"].([]interface{})[0].(map[string]interface{}))
+	})
+}
+
+func (f *sigstoreFixture) leafCertPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: f.leafCert.Raw})
+}
+
+func generateTestKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func marshalJSON(t *testing.T, v interface{}) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
@@ -0,0 +1,108 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package install
+
+import (
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/src"
+)
+
+// Origin represents how the binary was obtained
+type Origin string
+
+const (
+	// OriginFound means the binary was found on disk (e.g. in $PATH)
+	OriginFound Origin = "found"
+
+	// OriginInstalled means the binary was downloaded and installed
+	OriginInstalled Origin = "installed"
+
+	// OriginBuilt means the binary was built from source code
+	OriginBuilt Origin = "built"
+)
+
+// InstallResult describes the outcome of Installer.EnsureWithResult
+// or Installer.InstallWithResult
+type InstallResult struct {
+	// Source is the source which provided the binary
+	Source src.Source
+	Origin Origin
+
+	// ExecPath is the path to the binary
+	ExecPath string
+
+	// Version is the resolved version of the binary,
+	// nil if the source doesn't know it (e.g. fs.AnyVersion)
+	Version *version.Version
+
+	// SHA256 is the hex-encoded checksum of the downloaded archive
+	SHA256 string
+
+	// SigningKeyID and SigningKeyFingerprint identify the PGP key
+	// which signed the checksums of the downloaded archive
+	SigningKeyID          string
+	SigningKeyFingerprint string
+
+	// LicensePaths are paths of any license files placed
+	// alongside (or separately from) the binary
+	LicensePaths []string
+
+	// ExtraFiles are paths of any other files placed alongside the binary
+	ExtraFiles []string
+
+	// StartedAt represents when the first source was attempted
+	// and Duration how long it took to obtain the binary
+	StartedAt time.Time
+	Duration  time.Duration
+
+	// SkippedSources describes sources attempted before Source,
+	// along with the reason why each of them was skipped
+	SkippedSources []*SkippedSource
+}
+
+// InstallPlan describes what Installer.Ensure would do,
+// as determined by Installer.Plan
+type InstallPlan struct {
+	// Source is the first source which would be used,
+	// i.e. which resolved successfully or can't be resolved upfront
+	Source src.Source
+	Origin Origin
+
+	// Resolution describes what the source would find, install or build,
+	// nil if the source doesn't implement src.Resolvable
+	Resolution *src.Resolution
+
+	// SkippedSources describes sources before Source which would be
+	// skipped, along with the reason why each of them would be skipped
+	SkippedSources []*SkippedSource
+}
+
+// SkippedSource describes a source which was skipped
+type SkippedSource struct {
+	Source src.Source
+	Err    error
+}
+
+func newInstallResult(source src.Source, origin Origin, execPath string) *InstallResult {
+	ir := &InstallResult{
+		Source:   source,
+		Origin:   origin,
+		ExecPath: execPath,
+	}
+
+	if ap, ok := source.(src.ArtifactProvider); ok {
+		if a := ap.Artifact(); a != nil {
+			ir.Version = a.Version
+			ir.SHA256 = a.SHA256
+			ir.SigningKeyID = a.SigningKeyID
+			ir.SigningKeyFingerprint = a.SigningKeyFingerprint
+			ir.LicensePaths = a.LicensePaths
+			ir.ExtraFiles = a.ExtraFiles
+		}
+	}
+
+	return ir
+}
@@ -0,0 +1,38 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package src
+
+import (
+	"github.com/hashicorp/go-version"
+)
+
+// Artifact describes the binary obtained by the last successful
+// Find, Install or Build of a source. Fields which are not known
+// to the source (e.g. SHA256 for binaries found on disk) are empty.
+type Artifact struct {
+	// Version is the resolved version of the binary
+	Version *version.Version
+
+	// SHA256 is the hex-encoded checksum of the downloaded archive
+	SHA256 string
+
+	// SigningKeyID and SigningKeyFingerprint identify the PGP key which
+	// signed the checksums, empty if checksum verification was skipped
+	SigningKeyID          string
+	SigningKeyFingerprint string
+
+	// LicensePaths are paths of any unpacked license files
+	LicensePaths []string
+
+	// ExtraFiles are paths of any other files placed alongside
+	// the binary, e.g. when unpacking the archive
+	ExtraFiles []string
+}
+
+// ArtifactProvider is implemented by sources which can describe
+// the binary they found, installed or built
+type ArtifactProvider interface {
+	Source
+	Artifact() *Artifact
+}
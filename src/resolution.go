@@ -0,0 +1,32 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package src
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-version"
+)
+
+// Resolution describes what a source would find, install or build,
+// as determined without downloading or building anything
+type Resolution struct {
+	// Version is the version which would be obtained,
+	// nil if it can't be determined upfront
+	Version *version.Version
+
+	// ExecPath is the path of the binary which would be found,
+	// or where it would be installed if known upfront
+	ExecPath string
+
+	// ArchiveURL is the URL of the archive which would be downloaded
+	ArchiveURL string
+}
+
+// Resolvable is implemented by sources which can determine
+// what they would do without making any filesystem changes
+type Resolvable interface {
+	Source
+	Resolve(ctx context.Context) (*Resolution, error)
+}
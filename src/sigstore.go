@@ -0,0 +1,23 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package src
+
+// SigstoreOptions represents options for verification of a Sigstore
+// bundle published alongside the checksums, in addition to the PGP signature.
+// Verification is done offline against the supplied trust root.
+type SigstoreOptions struct {
+	// TrustedRoot represents the Sigstore trust root in JSON format
+	// (i.e. trusted_root.json as distributed via Sigstore TUF repository)
+	TrustedRoot []byte
+
+	// CertificateIdentity and CertificateOIDCIssuer represent
+	// the expected identity which signed the checksums
+	CertificateIdentity   string
+	CertificateOIDCIssuer string
+
+	// PredicateType represents the required type of attestation
+	// of the checksums, e.g. https://slsa.dev/provenance/v1
+	// (leave empty to accept plain signatures)
+	PredicateType string
+}
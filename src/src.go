@@ -10,7 +10,20 @@ import (
 	isrc "github.com/hashicorp/hc-install/internal/src"
 )
 
+// InstallSrcSigil is returned by IsSourceImpl to mark a type as a Source.
+//
+// Sources outside of this module can implement Source by returning it:
+//
+//	func (*MySource) IsSourceImpl() src.InstallSrcSigil {
+//		return src.InstallSrcSigil{}
+//	}
+type InstallSrcSigil = isrc.InstallSrcSigil
+
 // Source represents an installer, finder, or builder
+//
+// Besides IsSourceImpl, a Source must implement at least one of
+// Installable, Findable or Buildable to be used with the Installer
+// and may optionally implement Validatable, Removable or LoggerSettable.
 type Source interface {
 	IsSourceImpl() isrc.InstallSrcSigil
 }